@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ErrBulkUnsupported indica que o backend não oferece o endpoint bulk
+var ErrBulkUnsupported = errors.New("endpoint bulk não suportado pelo backend")
+
+// ErrBulkUnconfirmed indica um item que a resposta do bulk não confirmou nem rejeitou:
+// ele pode ter sido criado, então precisa ser reverificado antes da criação individual
+var ErrBulkUnconfirmed = errors.New("item sem confirmação na resposta do bulk")
+
+// BulkItemResult é o resultado de um item enviado ao endpoint bulk, na posição em que foi enviado
+type BulkItemResult struct {
+	ID  uuid.UUID
+	Err error
+}
+
+// CreateProductsBulk cria produtos em lote via POST /product/bulk.
+// Retorna um resultado por item do lote; itens que o backend não confirmou ficam com Err preenchido.
+// Se o próprio lote falhar, todos os itens ficam sem confirmação e o erro é retornado.
+func (c *APIClientV2) CreateProductsBulk(ctx context.Context, payloads []map[string]interface{}) ([]BulkItemResult, error) {
+	resp, status, err := c.doRequest(ctx, "POST", "/product/bulk", map[string]interface{}{
+		"products": payloads,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch status {
+	case 200, 201, 207:
+	case 404, 405, 501:
+		return nil, ErrBulkUnsupported
+	default:
+		return nil, newAPIError(status, resp)
+	}
+
+	names := make([]string, len(payloads))
+	for i, payload := range payloads {
+		names[i], _ = payload["name"].(string)
+	}
+
+	return parseBulkResults(resp, names), nil
+}
+
+// parseBulkResults mapeia a resposta do bulk de volta às posições do lote.
+// Formatos aceitos:
+//
+//	{"data": [{"id": ...}, {"error": ...}]}                      (mesma ordem do envio)
+//	{"data": {"results": [{"index": 0, "id": ...}]}}              (com ou sem "index")
+//	{"data": {"created": [...], "errors": [{"index": 1, ...}]}}
+//
+// Itens criados sem "index" em "created" são associados pelo nome.
+func parseBulkResults(resp map[string]interface{}, names []string) []BulkItemResult {
+	n := len(names)
+	results := make([]BulkItemResult, n)
+	for i := range results {
+		results[i].Err = ErrBulkUnconfirmed
+	}
+
+	apply := func(entries []interface{}, positional bool) {
+		for pos, e := range entries {
+			entry, ok := e.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			idx := -1
+			if v, ok := entry["index"].(float64); ok {
+				idx = int(v)
+			} else if positional {
+				idx = pos
+			}
+			if idx < 0 || idx >= n {
+				continue
+			}
+
+			results[idx] = bulkEntryResult(entry)
+		}
+	}
+
+	var container map[string]interface{}
+	switch data := resp["data"].(type) {
+	case []interface{}:
+		apply(data, true)
+		return results
+	case map[string]interface{}:
+		container = data
+	default:
+		container = resp
+	}
+
+	if entries, ok := container["results"].([]interface{}); ok {
+		apply(entries, true)
+	}
+	if entries, ok := container["errors"].([]interface{}); ok {
+		for _, e := range entries {
+			if entry, ok := e.(map[string]interface{}); ok {
+				entry["success"] = false
+			}
+		}
+		apply(entries, false)
+	}
+	if entries, ok := container["created"].([]interface{}); ok {
+		apply(entries, false)
+		matchCreatedByName(results, names, entries)
+	}
+
+	return results
+}
+
+// bulkEntryResult interpreta um item da resposta do bulk
+func bulkEntryResult(entry map[string]interface{}) BulkItemResult {
+	if success, ok := entry["success"].(bool); ok && !success {
+		return BulkItemResult{Err: fmt.Errorf("%s", bulkEntryMessage(entry))}
+	}
+
+	source := entry
+	if data, ok := entry["data"].(map[string]interface{}); ok {
+		source = data
+	}
+
+	if idStr, ok := source["id"].(string); ok {
+		if id, err := uuid.Parse(idStr); err == nil {
+			return BulkItemResult{ID: id}
+		}
+	}
+
+	for _, key := range []string{"error", "message"} {
+		if _, ok := entry[key]; ok {
+			return BulkItemResult{Err: fmt.Errorf("%s", bulkEntryMessage(entry))}
+		}
+	}
+	return BulkItemResult{Err: ErrBulkUnconfirmed}
+}
+
+// bulkEntryMessage extrai a mensagem de erro de um item rejeitado
+func bulkEntryMessage(entry map[string]interface{}) string {
+	for _, key := range []string{"error", "message"} {
+		if msg, ok := entry[key].(string); ok && msg != "" {
+			return msg
+		}
+	}
+	return "rejeitado pelo bulk"
+}
+
+// matchCreatedByName associa itens de "created" sem "index" ao primeiro item ainda sem confirmação com o mesmo nome.
+// Itens já confirmados ou rejeitados pelo backend não são sobrescritos.
+func matchCreatedByName(results []BulkItemResult, names []string, created []interface{}) {
+	for _, e := range created {
+		entry, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if _, hasIndex := entry["index"]; hasIndex {
+			continue
+		}
+
+		name, _ := entry["name"].(string)
+		for i := range results {
+			if errors.Is(results[i].Err, ErrBulkUnconfirmed) && names[i] == name {
+				results[i] = bulkEntryResult(entry)
+				break
+			}
+		}
+	}
+}
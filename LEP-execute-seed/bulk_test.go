@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseBulkResults(t *testing.T) {
+	const (
+		id1 = "11111111-1111-1111-1111-111111111111"
+		id2 = "22222222-2222-2222-2222-222222222222"
+	)
+	names := []string{"Risoto", "Lasanha", "Tiramisù"}
+
+	tests := []struct {
+		name string
+		resp string
+		want []string // ID, "erro: <mensagem>" ou "sem confirmação"
+	}{
+		{
+			name: "lista na ordem do envio",
+			resp: `{"data": [{"id": "` + id1 + `"}, {"error": "preço inválido"}, {"id": "` + id2 + `"}]}`,
+			want: []string{id1, "erro: preço inválido", id2},
+		},
+		{
+			name: "lista mais curta que o lote",
+			resp: `{"data": [{"id": "` + id1 + `"}]}`,
+			want: []string{id1, "sem confirmação", "sem confirmação"},
+		},
+		{
+			name: "results com index",
+			resp: `{"data": {"results": [{"index": 2, "id": "` + id2 + `"}, {"index": 0, "success": false, "message": "duplicado"}]}}`,
+			want: []string{"erro: duplicado", "sem confirmação", id2},
+		},
+		{
+			name: "created e errors",
+			resp: `{"data": {"created": [{"index": 0, "id": "` + id1 + `"}], "errors": [{"index": 1, "error": "categoria inexistente"}]}}`,
+			want: []string{id1, "erro: categoria inexistente", "sem confirmação"},
+		},
+		{
+			name: "created sem index associado pelo nome",
+			resp: `{"data": {"created": [{"name": "Tiramisù", "id": "` + id2 + `"}, {"name": "Risoto", "id": "` + id1 + `"}]}}`,
+			want: []string{id1, "sem confirmação", id2},
+		},
+		{
+			name: "created sem index não sobrescreve item rejeitado",
+			resp: `{"data": {"errors": [{"index": 0, "error": "duplicado"}], "created": [{"name": "Risoto", "id": "` + id1 + `"}]}}`,
+			want: []string{"erro: duplicado", "sem confirmação", "sem confirmação"},
+		},
+		{
+			name: "errors sem mensagem",
+			resp: `{"data": {"errors": [{"index": 1}]}}`,
+			want: []string{"sem confirmação", "erro: rejeitado pelo bulk", "sem confirmação"},
+		},
+		{
+			name: "item sem id nem erro",
+			resp: `{"data": [{"id": "` + id1 + `"}, {"status": "ok"}, {"id": "não é uuid"}]}`,
+			want: []string{id1, "sem confirmação", "sem confirmação"},
+		},
+		{
+			name: "index fora do lote é ignorado",
+			resp: `{"results": [{"index": 7, "id": "` + id1 + `"}, {"index": 1, "data": {"id": "` + id2 + `"}}]}`,
+			want: []string{"sem confirmação", id2, "sem confirmação"},
+		},
+		{
+			name: "resposta sem resultados",
+			resp: `{"message": "ok"}`,
+			want: []string{"sem confirmação", "sem confirmação", "sem confirmação"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp map[string]interface{}
+			if err := json.Unmarshal([]byte(tt.resp), &resp); err != nil {
+				t.Fatalf("resposta inválida no teste: %v", err)
+			}
+
+			var got []string
+			for _, r := range parseBulkResults(resp, names) {
+				switch {
+				case errors.Is(r.Err, ErrBulkUnconfirmed):
+					got = append(got, "sem confirmação")
+				case r.Err != nil:
+					got = append(got, "erro: "+r.Err.Error())
+				default:
+					got = append(got, r.ID.String())
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseBulkResults() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,453 @@
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// bundleManifestName é o manifesto na raiz do bundle
+const bundleManifestName = "manifest.json"
+
+// bundleVersion é a versão do formato de bundle gerada e aceita por este seeder
+const bundleVersion = 1
+
+// BundleManifest descreve o conteúdo de um bundle (.tar.gz ou .zip)
+type BundleManifest struct {
+	Version   int               `json:"version"`
+	CreatedAt time.Time         `json:"created_at"`
+	Seeds     []string          `json:"seeds"` // arquivos de seed, na ordem de execução
+	Files     map[string]string `json:"files"` // caminho no bundle -> sha256 (seeds e assets)
+}
+
+// SeedBundle é um bundle extraído e verificado num diretório temporário
+type SeedBundle struct {
+	Path     string
+	Dir      string
+	Manifest BundleManifest
+	seeds    map[string]string // nome exibido (bundle:seed) -> caminho extraído
+}
+
+// isBundle indica se o arquivo é um bundle pela extensão
+func isBundle(path string) bool {
+	lower := strings.ToLower(path)
+	return strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".tgz") || strings.HasSuffix(lower, ".zip")
+}
+
+// referencedFiles lista os arquivos que o seed referencia (image_path de categorias e produtos), inclusive nos projetos
+func (s *SeedData) referencedFiles() []string {
+	var paths []string
+	add := func(path string) {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+
+	for _, c := range s.Categories {
+		add(c.ImagePath)
+	}
+	for _, p := range s.Products {
+		add(p.ImagePath)
+	}
+	for i := range s.Projects {
+		paths = append(paths, s.Projects[i].referencedFiles()...)
+	}
+	return paths
+}
+
+// bundleEntryName valida um caminho relativo e o converte para o nome usado dentro do bundle
+func bundleEntryName(path string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(path))
+	if !filepath.IsLocal(clean) {
+		return "", fmt.Errorf("caminho %q precisa ser relativo e ficar dentro do diretório do seed", path)
+	}
+	name := filepath.ToSlash(clean)
+	if name == bundleManifestName {
+		return "", fmt.Errorf("caminho %q é reservado para o manifesto", path)
+	}
+	return name, nil
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateBundle reúne os seeds e os arquivos referenciados por eles em out (.tar.gz, .tgz ou .zip).
+// Os caminhos no bundle são relativos ao diretório de cada seed, como na execução normal.
+func CreateBundle(out string, seedFiles []string) (*BundleManifest, error) {
+	if !isBundle(out) {
+		return nil, fmt.Errorf("formato de bundle não suportado: %s (use .tar.gz, .tgz ou .zip)", out)
+	}
+
+	manifest := &BundleManifest{Version: bundleVersion, CreatedAt: time.Now().UTC(), Files: make(map[string]string)}
+	contents := make(map[string][]byte)
+
+	add := func(name string, data []byte, source string) error {
+		hash := sha256Hex(data)
+		if existing, ok := manifest.Files[name]; ok && existing != hash {
+			return fmt.Errorf("%s: conflito com outro arquivo de mesmo caminho no bundle (%s)", source, name)
+		}
+		manifest.Files[name] = hash
+		contents[name] = data
+		return nil
+	}
+
+	for _, seedFile := range seedFiles {
+		seedData, err := LoadSeedData(seedFile)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", seedFile, err)
+		}
+		data, err := os.ReadFile(seedFile)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler %s: %w", seedFile, err)
+		}
+
+		seedName := filepath.Base(seedFile)
+		if err := add(seedName, data, seedFile); err != nil {
+			return nil, err
+		}
+		manifest.Seeds = append(manifest.Seeds, seedName)
+
+		for _, ref := range seedData.referencedFiles() {
+			name, err := bundleEntryName(ref)
+			if err != nil {
+				return nil, fmt.Errorf("%s: image_path: %w", seedFile, err)
+			}
+			asset, err := os.ReadFile(filepath.Join(filepath.Dir(seedFile), filepath.FromSlash(name)))
+			if err != nil {
+				return nil, fmt.Errorf("%s: erro ao ler %s: %w", seedFile, ref, err)
+			}
+			if err := add(name, asset, seedFile); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao serializar manifesto: %w", err)
+	}
+
+	names := make([]string, 0, len(contents))
+	for name := range contents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	if strings.HasSuffix(strings.ToLower(out), ".zip") {
+		err = writeZipBundle(&buf, manifestData, names, contents)
+	} else {
+		err = writeTarBundle(&buf, manifestData, names, contents)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao gerar bundle: %w", err)
+	}
+
+	if err := os.WriteFile(out, buf.Bytes(), 0644); err != nil {
+		return nil, fmt.Errorf("erro ao gravar bundle: %w", err)
+	}
+	return manifest, nil
+}
+
+func writeTarBundle(w io.Writer, manifest []byte, names []string, contents map[string][]byte) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	write := func(name string, data []byte) error {
+		hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), ModTime: time.Now(), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+
+	if err := write(bundleManifestName, manifest); err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := write(name, contents[name]); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func writeZipBundle(w io.Writer, manifest []byte, names []string, contents map[string][]byte) error {
+	zw := zip.NewWriter(w)
+
+	write := func(name string, data []byte) error {
+		f, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+		if err != nil {
+			return err
+		}
+		_, err = f.Write(data)
+		return err
+	}
+
+	if err := write(bundleManifestName, manifest); err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := write(name, contents[name]); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// OpenBundle extrai o bundle num diretório temporário e confere o manifesto:
+// todo arquivo listado precisa existir com o sha256 declarado e nenhum arquivo extra é aceito
+func OpenBundle(path string) (*SeedBundle, error) {
+	dir, err := os.MkdirTemp("", "lep-seed-bundle-")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar diretório temporário: %w", err)
+	}
+	bundle := &SeedBundle{Path: path, Dir: dir, seeds: make(map[string]string)}
+
+	if err := bundle.extractAndVerify(); err != nil {
+		bundle.Close()
+		return nil, fmt.Errorf("bundle %s: %w", path, err)
+	}
+	return bundle, nil
+}
+
+func (b *SeedBundle) extractAndVerify() error {
+	hashes := make(map[string]string)
+	extract := func(name string, r io.Reader) error {
+		entry := bundleManifestName
+		if name != bundleManifestName {
+			var err error
+			if entry, err = bundleEntryName(name); err != nil {
+				return err
+			}
+		}
+		if _, ok := hashes[entry]; ok {
+			return fmt.Errorf("arquivo %s repetido", entry)
+		}
+
+		target := filepath.Join(b.Dir, filepath.FromSlash(entry))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		h := sha256.New()
+		if _, err := io.Copy(io.MultiWriter(f, h), r); err != nil {
+			return fmt.Errorf("erro ao extrair %s: %w", entry, err)
+		}
+		hashes[entry] = hex.EncodeToString(h.Sum(nil))
+		return nil
+	}
+
+	var err error
+	if strings.HasSuffix(strings.ToLower(b.Path), ".zip") {
+		err = extractZip(b.Path, extract)
+	} else {
+		err = extractTar(b.Path, extract)
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, ok := hashes[bundleManifestName]; !ok {
+		return fmt.Errorf("%s ausente", bundleManifestName)
+	}
+	data, err := os.ReadFile(filepath.Join(b.Dir, bundleManifestName))
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &b.Manifest); err != nil {
+		return fmt.Errorf("erro ao parsear %s: %w", bundleManifestName, err)
+	}
+	if b.Manifest.Version != bundleVersion {
+		return fmt.Errorf("versão de bundle %d não suportada (esperada %d)", b.Manifest.Version, bundleVersion)
+	}
+
+	var problems []string
+	for name, want := range b.Manifest.Files {
+		got, ok := hashes[name]
+		switch {
+		case !ok:
+			problems = append(problems, name+" listado no manifesto mas ausente")
+		case !strings.EqualFold(got, want):
+			problems = append(problems, name+" com checksum divergente")
+		}
+	}
+	for name := range hashes {
+		if _, ok := b.Manifest.Files[name]; !ok && name != bundleManifestName {
+			problems = append(problems, name+" não listado no manifesto")
+		}
+	}
+	if len(b.Manifest.Seeds) == 0 {
+		problems = append(problems, "manifesto sem seeds")
+	}
+	for _, seed := range b.Manifest.Seeds {
+		if _, ok := b.Manifest.Files[seed]; !ok {
+			problems = append(problems, "seed "+seed+" não listado em files")
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("verificação falhou: %s", strings.Join(problems, "; "))
+	}
+
+	for _, seed := range b.Manifest.Seeds {
+		b.seeds[filepath.Base(b.Path)+":"+seed] = filepath.Join(b.Dir, filepath.FromSlash(seed))
+	}
+	return nil
+}
+
+func extractTar(path string, extract func(name string, r io.Reader) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("gzip inválido: %w", err)
+	}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("tar inválido: %w", err)
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			continue
+		case tar.TypeReg:
+			if err := extract(hdr.Name, tr); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%s: só arquivos regulares são aceitos no bundle", hdr.Name)
+		}
+	}
+}
+
+func extractZip(path string, extract func(name string, r io.Reader) error) error {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return fmt.Errorf("zip inválido: %w", err)
+	}
+	defer zr.Close()
+
+	for _, zf := range zr.File {
+		if zf.FileInfo().IsDir() {
+			continue
+		}
+		if !zf.Mode().IsRegular() {
+			return fmt.Errorf("%s: só arquivos regulares são aceitos no bundle", zf.Name)
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return fmt.Errorf("erro ao abrir %s: %w", zf.Name, err)
+		}
+		err = extract(zf.Name, rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SeedFiles retorna os seeds do bundle na ordem do manifesto, com o nome exibido bundle:seed
+func (b *SeedBundle) SeedFiles() []string {
+	files := make([]string, 0, len(b.Manifest.Seeds))
+	for _, seed := range b.Manifest.Seeds {
+		files = append(files, filepath.Base(b.Path)+":"+seed)
+	}
+	return files
+}
+
+// Resolve retorna o caminho extraído do seed; sem bundle, o próprio nome
+func (b *SeedBundle) Resolve(seedFile string) string {
+	if b == nil {
+		return seedFile
+	}
+	if path, ok := b.seeds[seedFile]; ok {
+		return path
+	}
+	return seedFile
+}
+
+// Close remove o diretório da extração
+func (b *SeedBundle) Close() error {
+	if b == nil {
+		return nil
+	}
+	return os.RemoveAll(b.Dir)
+}
+
+// openSeedFiles determina os seeds a executar; com -file apontando para um bundle,
+// ele é extraído e verificado e os seeds são lidos de lá (feche o bundle ao terminar)
+func openSeedFiles(configFile string, logger *Logger) ([]string, *SeedBundle, error) {
+	if !isBundle(configFile) {
+		return determineSeedFiles(configFile, logger), nil, nil
+	}
+
+	bundle, err := OpenBundle(configFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	logger.Info("Bundle %s verificado: %d seed(s), %d arquivo(s)", configFile, len(bundle.Manifest.Seeds), len(bundle.Manifest.Files))
+	return bundle.SeedFiles(), bundle, nil
+}
+
+// runBundleCommand executa "bundle create <saida> [seeds...]"; sem seeds, usa os de -file
+func runBundleCommand(config *Config, logger *Logger) int {
+	if len(config.Command) < 3 || config.Command[1] != "create" {
+		logger.Error("Uso: bundle create <saida.tar.gz|.zip> [seeds...]")
+		return exitUsage
+	}
+	out := config.Command[2]
+
+	seedFiles := config.Command[3:]
+	if len(seedFiles) == 0 {
+		if isBundle(config.Seed.File) {
+			logger.Error("-file já é um bundle: %s", config.Seed.File)
+			return exitUsage
+		}
+		seedFiles = determineSeedFiles(config.Seed.File, logger)
+	}
+	if len(seedFiles) == 0 {
+		logger.Error("Nenhum arquivo de seed encontrado para o bundle")
+		return exitValidation
+	}
+
+	manifest, err := CreateBundle(out, seedFiles)
+	if err != nil {
+		logger.Error("%v", err)
+		return exitValidation
+	}
+
+	logger.Success("Bundle gravado em %s: %d seed(s), %d arquivo(s)", out, len(manifest.Seeds), len(manifest.Files))
+	return exitOK
+}
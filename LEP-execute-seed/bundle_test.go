@@ -0,0 +1,193 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeTestBundle grava um bundle com os arquivos informados; o manifesto lista apenas listed,
+// com o sha256 real, e pode ser alterado por mutate antes de ser gravado
+func writeTestBundle(t *testing.T, ext string, files map[string]string, listed []string, mutate func(*BundleManifest)) string {
+	t.Helper()
+
+	manifest := BundleManifest{Version: bundleVersion, CreatedAt: time.Now(), Seeds: []string{"seed.json"}, Files: map[string]string{}}
+	for _, name := range listed {
+		manifest.Files[name] = sha256Hex([]byte(files[name]))
+	}
+	if mutate != nil {
+		mutate(&manifest)
+	}
+	manifestData, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := make([]string, 0, len(files))
+	contents := make(map[string][]byte, len(files))
+	for name, data := range files {
+		names = append(names, name)
+		contents[name] = []byte(data)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	if ext == ".zip" {
+		err = writeZipBundle(&buf, manifestData, names, contents)
+	} else {
+		err = writeTarBundle(&buf, manifestData, names, contents)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "bundle"+ext)
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestOpenBundle(t *testing.T) {
+	seed := `{"products": [{"name": "Risoto", "image_path": "img/risoto.png"}]}`
+	valid := map[string]string{"seed.json": seed, "img/risoto.png": "png"}
+	validListed := []string{"seed.json", "img/risoto.png"}
+	outside := filepath.Join(os.TempDir(), "lep-bundle-test-fora.png")
+
+	with := func(extra map[string]string) map[string]string {
+		files := map[string]string{}
+		for k, v := range valid {
+			files[k] = v
+		}
+		for k, v := range extra {
+			files[k] = v
+		}
+		return files
+	}
+
+	tests := []struct {
+		name    string
+		ext     string
+		files   map[string]string
+		listed  []string
+		mutate  func(*BundleManifest)
+		wantErr string
+	}{
+		{name: "tar.gz válido", ext: ".tar.gz", files: valid, listed: validListed},
+		{name: "zip válido", ext: ".zip", files: valid, listed: validListed},
+		{
+			name:    "caminho que sai do diretório",
+			ext:     ".tar.gz",
+			files:   with(map[string]string{"../lep-bundle-test-fora.png": "x"}),
+			listed:  validListed,
+			wantErr: "precisa ser relativo",
+		},
+		{
+			name:    "caminho absoluto no zip",
+			ext:     ".zip",
+			files:   with(map[string]string{"/etc/lep-bundle.png": "x"}),
+			listed:  validListed,
+			wantErr: "precisa ser relativo",
+		},
+		{
+			name:    "checksum divergente",
+			ext:     ".tar.gz",
+			files:   valid,
+			listed:  validListed,
+			mutate:  func(m *BundleManifest) { m.Files["img/risoto.png"] = sha256Hex([]byte("outra imagem")) },
+			wantErr: "img/risoto.png com checksum divergente",
+		},
+		{
+			name:    "arquivo fora do manifesto",
+			ext:     ".zip",
+			files:   with(map[string]string{"img/extra.png": "x"}),
+			listed:  validListed,
+			wantErr: "img/extra.png não listado no manifesto",
+		},
+		{
+			name:    "arquivo do manifesto ausente",
+			ext:     ".tar.gz",
+			files:   valid,
+			listed:  validListed,
+			mutate:  func(m *BundleManifest) { m.Files["img/lasanha.png"] = sha256Hex([]byte("x")) },
+			wantErr: "img/lasanha.png listado no manifesto mas ausente",
+		},
+		{
+			name:    "seed fora de files",
+			ext:     ".tar.gz",
+			files:   valid,
+			listed:  validListed,
+			mutate:  func(m *BundleManifest) { m.Seeds = append(m.Seeds, "outro.json") },
+			wantErr: "seed outro.json não listado em files",
+		},
+		{
+			name:    "versão não suportada",
+			ext:     ".zip",
+			files:   valid,
+			listed:  validListed,
+			mutate:  func(m *BundleManifest) { m.Version = bundleVersion + 1 },
+			wantErr: "não suportada",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTestBundle(t, tt.ext, tt.files, tt.listed, tt.mutate)
+
+			bundle, err := OpenBundle(path)
+			if tt.wantErr != "" {
+				if err == nil {
+					bundle.Close()
+					t.Fatalf("OpenBundle() sem erro, want contendo %q", tt.wantErr)
+				}
+				if !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("OpenBundle() erro = %v, want contendo %q", err, tt.wantErr)
+				}
+				if _, statErr := os.Stat(outside); statErr == nil {
+					t.Fatalf("arquivo extraído fora do diretório do bundle: %s", outside)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("OpenBundle() erro inesperado: %v", err)
+			}
+			defer bundle.Close()
+
+			seeds := bundle.SeedFiles()
+			if len(seeds) != 1 || seeds[0] != "bundle"+tt.ext+":seed.json" {
+				t.Fatalf("SeedFiles() = %v", seeds)
+			}
+			data, err := os.ReadFile(filepath.Join(filepath.Dir(bundle.Resolve(seeds[0])), "img", "risoto.png"))
+			if err != nil || string(data) != "png" {
+				t.Errorf("imagem extraída = %q, %v", data, err)
+			}
+		})
+	}
+}
+
+func TestOpenBundleRejectsSymlink(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	if err := tw.WriteHeader(&tar.Header{Name: "img/risoto.png", Linkname: "/etc/passwd", Typeflag: tar.TypeSymlink}); err != nil {
+		t.Fatal(err)
+	}
+	tw.Close()
+	gz.Close()
+
+	path := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := OpenBundle(path); err == nil || !strings.Contains(err.Error(), "só arquivos regulares") {
+		t.Fatalf("OpenBundle() erro = %v, want symlink rejeitado", err)
+	}
+}
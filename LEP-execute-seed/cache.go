@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// collectionCache guarda as coleções do backend já baixadas nesta execução,
+// para que buscas por nome não listem a coleção inteira a cada verificação.
+// As coleções são separadas por projeto, pois o mesmo cliente atende vários arquivos de seed.
+type collectionCache struct {
+	enabled     bool
+	collections map[string]*indexedCollection // projID + path -> coleção
+}
+
+// indexedCollection é uma coleção com índices por campo construídos sob demanda
+type indexedCollection struct {
+	items   []map[string]interface{}
+	indexes map[string]map[string]uuid.UUID // campo -> valor -> ID
+}
+
+func newCollectionCache(enabled bool) *collectionCache {
+	return &collectionCache{
+		enabled:     enabled,
+		collections: make(map[string]*indexedCollection),
+	}
+}
+
+func newIndexedCollection(items []map[string]interface{}) *indexedCollection {
+	return &indexedCollection{
+		items:   items,
+		indexes: make(map[string]map[string]uuid.UUID),
+	}
+}
+
+// get retorna a coleção em cache, se houver
+func (cc *collectionCache) get(key string) (*indexedCollection, bool) {
+	if !cc.enabled {
+		return nil, false
+	}
+	col, ok := cc.collections[key]
+	return col, ok
+}
+
+// put armazena a coleção baixada
+func (cc *collectionCache) put(key string, col *indexedCollection) {
+	if cc.enabled {
+		cc.collections[key] = col
+	}
+}
+
+// add registra uma entidade recém-criada numa coleção já carregada.
+// Se a coleção ainda não foi carregada não há nada a fazer: a próxima busca já trará a entidade.
+func (cc *collectionCache) add(key string, item map[string]interface{}) {
+	if col, ok := cc.get(key); ok {
+		col.add(item)
+	}
+}
+
+// invalidate descarta a coleção, forçando nova listagem na próxima busca
+func (cc *collectionCache) invalidate(key string) {
+	delete(cc.collections, key)
+}
+
+// find procura o ID da primeira entidade cujo campo tem o valor informado
+func (col *indexedCollection) find(field, value string) (uuid.UUID, bool) {
+	index, ok := col.indexes[field]
+	if !ok {
+		index = make(map[string]uuid.UUID, len(col.items))
+		for _, item := range col.items {
+			col.indexItem(index, field, item)
+		}
+		col.indexes[field] = index
+	}
+
+	id, ok := index[value]
+	return id, ok
+}
+
+// add inclui uma entidade na coleção e nos índices já construídos
+func (col *indexedCollection) add(item map[string]interface{}) {
+	col.items = append(col.items, item)
+	for field, index := range col.indexes {
+		col.indexItem(index, field, item)
+	}
+}
+
+// indexItem indexa a entidade pelo campo, mantendo a primeira ocorrência (mesmo critério da busca linear)
+func (col *indexedCollection) indexItem(index map[string]uuid.UUID, field string, item map[string]interface{}) {
+	value, ok := cacheKeyValue(item[field])
+	if !ok {
+		return
+	}
+	if _, exists := index[value]; exists {
+		return
+	}
+
+	idStr, ok := item["id"].(string)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return
+	}
+	index[value] = id
+}
+
+// cacheKeyValue normaliza valores JSON para chave de índice (números chegam como float64)
+func cacheKeyValue(v interface{}) (string, bool) {
+	switch val := v.(type) {
+	case string:
+		return val, true
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64), true
+	case int:
+		return strconv.Itoa(val), true
+	}
+	return "", false
+}
+
+// collectionRoot retorna a coleção afetada por um path ("/product/123/tag/9?x=1" -> "/product")
+func collectionRoot(path string) string {
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	trimmed := strings.TrimPrefix(path, "/")
+	if i := strings.Index(trimmed, "/"); i >= 0 {
+		trimmed = trimmed[:i]
+	}
+	return "/" + trimmed
+}
+
+// cacheKey identifica a coleção do projeto atual
+func (c *APIClientV2) cacheKey(path string) string {
+	return c.projID + collectionRoot(path)
+}
+
+// collection retorna a coleção do path, do cache quando disponível
+func (c *APIClientV2) collection(ctx context.Context, path string) (*indexedCollection, error) {
+	key := c.cacheKey(path)
+	if col, ok := c.cache.get(key); ok {
+		return col, nil
+	}
+
+	items, err := c.listAll(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	col := newIndexedCollection(items)
+	c.cache.put(key, col)
+	if c.cache.enabled {
+		c.logger.Debug("[cache] %s carregado (%d itens)", path, len(items))
+	}
+	return col, nil
+}
+
+// findByField busca o ID de uma entidade da coleção pelo valor de um campo.
+// Com cache ativo a coleção inteira é carregada uma vez; sem cache as páginas são percorridas
+// até o primeiro resultado, usando filtro server-side quando o backend oferece um.
+func (c *APIClientV2) findByField(ctx context.Context, path, field, value string) (uuid.UUID, error) {
+	if !c.cache.enabled {
+		return c.findByFieldUncached(ctx, path, field, value)
+	}
+
+	col, err := c.collection(ctx, path)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if id, ok := col.find(field, value); ok {
+		return id, nil
+	}
+
+	return uuid.Nil, fmt.Errorf("não encontrado")
+}
+
+// findByFieldUncached percorre as páginas da coleção sem usar o cache
+func (c *APIClientV2) findByFieldUncached(ctx context.Context, path, field, value string) (uuid.UUID, error) {
+	var filters url.Values
+	if param, ok := lookupFilters[path][field]; ok {
+		filters = url.Values{param: []string{value}}
+	}
+
+	item, err := c.findFirst(ctx, path, filters, func(item map[string]interface{}) bool {
+		v, ok := cacheKeyValue(item[field])
+		return ok && v == value
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if item == nil {
+		return uuid.Nil, fmt.Errorf("não encontrado")
+	}
+
+	idStr, _ := item["id"].(string)
+	return uuid.Parse(idStr)
+}
+
+// rememberCreated registra no cache uma entidade criada com sucesso
+func (c *APIClientV2) rememberCreated(path string, payload interface{}, id uuid.UUID) {
+	fields, ok := payload.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	item := make(map[string]interface{}, len(fields)+1)
+	for k, v := range fields {
+		item[k] = v
+	}
+	item["id"] = id.String()
+
+	c.cache.add(c.cacheKey(path), item)
+}
+
+// invalidateAfterWrite descarta a coleção afetada por uma escrita bem-sucedida.
+// POST na raiz da coleção é uma criação e já é registrado por doCreate.
+func (c *APIClientV2) invalidateAfterWrite(method, path string) {
+	if method == "GET" || (method == "POST" && path == collectionRoot(path)) {
+		return
+	}
+	c.cache.invalidate(c.cacheKey(path))
+}
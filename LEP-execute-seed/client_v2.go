@@ -2,13 +2,19 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
+	"lep-shared/logging"
+	"lep-shared/retry"
 )
 
 // APIClientV2 é um cliente HTTP otimizado para a API LEP
@@ -20,6 +26,8 @@ type APIClientV2 struct {
 	logger  *Logger
 	client  *http.Client
 	config  *Config
+	retry   retry.Policy
+	cache   *collectionCache
 }
 
 // NewAPIClientV2 cria novo cliente de API
@@ -28,9 +36,9 @@ func NewAPIClientV2(baseURL string, logger *Logger, config *Config) *APIClientV2
 		baseURL: baseURL,
 		logger:  logger,
 		config:  config,
-		client: &http.Client{
-			Timeout: time.Duration(config.Server.Timeout) * time.Second,
-		},
+		retry:   NewRetryPolicy(config),
+		cache:   newCollectionCache(config.Seed.Cache),
+		client:  &http.Client{}, // o prazo de cada requisição vem do contexto (ver doRequestOnce)
 	}
 }
 
@@ -41,11 +49,11 @@ func (c *APIClientV2) SetHeaders(token, orgID, projID string) {
 	c.projID = projID
 }
 
-// doRequest executa requisição com tratamento de erro
-func (c *APIClientV2) doRequest(method, path string, body interface{}) (map[string]interface{}, int, error) {
-	url := c.baseURL + path
-
-	var reqBody io.Reader
+// doRequest executa requisição com tratamento de erro e retry para falhas transitórias.
+// Métodos idempotentes são repetidos em erros de rede, 429, 502, 503 e 504.
+// POSTs só são repetidos quando é certo que a primeira tentativa não chegou ao backend;
+// para os demais casos use doCreate, que confirma a existência da entidade antes de repetir.
+func (c *APIClientV2) doRequest(ctx context.Context, method, path string, body interface{}) (map[string]interface{}, int, error) {
 	var jsonBodyBytes []byte
 
 	if body != nil {
@@ -59,17 +67,119 @@ func (c *APIClientV2) doRequest(method, path string, body interface{}) (map[stri
 		if c.config.Logging.ShowPayloads {
 			c.logger.Debug(fmt.Sprintf("[%s] Payload: %s", path, string(jsonBodyBytes)))
 		}
+	}
+
+	return c.sendWithRetry(ctx, method, path, retry.IsIdempotentMethod(method), func() (map[string]interface{}, int, http.Header, error) {
+		return c.doRequestOnce(ctx, method, path, jsonBodyBytes, "application/json")
+	})
+}
 
-		reqBody = bytes.NewBuffer(jsonBodyBytes)
+// sendWithRetry repete a tentativa enquanto a falha for transitória e repetir for seguro
+// (idempotent, ou a tentativa comprovadamente não chegou ao backend).
+// Com o contexto cancelado (Ctrl-C ou step_timeout) não há nova tentativa nem espera.
+func (c *APIClientV2) sendWithRetry(ctx context.Context, method, path string, idempotent bool, attemptFn func() (map[string]interface{}, int, http.Header, error)) (map[string]interface{}, int, error) {
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		result, status, header, err := attemptFn()
+
+		transient := (err != nil || retry.IsRetryableStatus(status)) && ctx.Err() == nil
+		safe := idempotent || retry.RequestNeverLanded(status, err)
+		if !transient || !safe || attempt >= c.retry.MaxAttempts {
+			if err == nil && status >= 200 && status < 300 {
+				c.invalidateAfterWrite(method, path)
+			}
+			if c.logger.Enabled(logging.LevelDebug) {
+				c.logger.With(Fields{
+					"method": method, "path": path, "status": status,
+					"duration_ms": time.Since(start).Milliseconds(), "attempts": attempt,
+				}).Debug("%s %s -> %s (%s)", method, path, describeFailure(status, err), time.Since(start).Round(time.Millisecond))
+			}
+			return result, status, err
+		}
+
+		delay := c.retry.Delay(attempt, header, time.Now())
+		c.logger.Warn("[%s %s] tentativa %d/%d falhou (%s), repetindo em %s",
+			method, path, attempt, c.retry.MaxAttempts, describeFailure(status, err), delay.Round(time.Millisecond))
+		if err := retry.Sleep(ctx, delay); err != nil {
+			return nil, 0, err
+		}
 	}
+}
 
-	req, err := http.NewRequest(method, url, reqBody)
+// doCreate executa um POST de criação com retry seguro, num único laço de tentativas.
+// Falhas que não chegaram ao backend (dial, 429) são repetidas direto. Quando a falha é ambígua
+// (erro de rede, 502, 503, 504) a entidade pode ter sido criada mesmo assim, então lookup é
+// consultado antes de repetir: se a entidade já existe, seu ID é devolvido como sucesso.
+func (c *APIClientV2) doCreate(ctx context.Context, path string, payload interface{}, lookup func() (uuid.UUID, error)) (map[string]interface{}, int, error) {
+	bodyBytes, err := json.Marshal(payload)
 	if err != nil {
-		return nil, 0, fmt.Errorf("erro ao criar request: %w", err)
+		return nil, 0, fmt.Errorf("erro ao serializar body: %w", err)
+	}
+	if c.config.Logging.ShowPayloads {
+		c.logger.Debug("[%s] Payload: %s", path, string(bodyBytes))
+	}
+
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		resp, status, header, err := c.doRequestOnce(ctx, "POST", path, bodyBytes, "application/json")
+
+		transient := (err != nil || retry.IsRetryableStatus(status)) && ctx.Err() == nil
+		neverLanded := retry.RequestNeverLanded(status, err)
+		if !transient || (!neverLanded && lookup == nil) || attempt >= c.retry.MaxAttempts {
+			if err == nil && (status == 200 || status == 201) {
+				if id, idErr := extractIDFromResponse(resp); idErr == nil {
+					c.rememberCreated(path, payload, id)
+				}
+			}
+			if c.logger.Enabled(logging.LevelDebug) {
+				c.logger.With(Fields{
+					"method": "POST", "path": path, "status": status,
+					"duration_ms": time.Since(start).Milliseconds(), "attempts": attempt,
+				}).Debug("POST %s -> %s (%s)", path, describeFailure(status, err), time.Since(start).Round(time.Millisecond))
+			}
+			return resp, status, err
+		}
+
+		if !neverLanded {
+			// A coleção em cache não reflete a tentativa ambígua, então a busca precisa ir ao backend
+			c.cache.invalidate(c.cacheKey(path))
+			if id, lookupErr := lookup(); lookupErr == nil && id != uuid.Nil {
+				c.logger.Debug("[POST %s] entidade encontrada após falha ambígua (%s), sem repetir", path, describeFailure(status, err))
+				return map[string]interface{}{"data": map[string]interface{}{"id": id.String()}}, 200, nil
+			}
+		}
+
+		delay := c.retry.Delay(attempt, header, time.Now())
+		c.logger.Warn("[POST %s] tentativa %d/%d falhou (%s), repetindo em %s",
+			path, attempt, c.retry.MaxAttempts, describeFailure(status, err), delay.Round(time.Millisecond))
+		if err := retry.Sleep(ctx, delay); err != nil {
+			return nil, 0, err
+		}
+	}
+}
+
+// doRequestOnce executa uma única tentativa HTTP, com prazo de server.timeout dentro do contexto do passo
+func (c *APIClientV2) doRequestOnce(ctx context.Context, method, path string, bodyBytes []byte, contentType string) (map[string]interface{}, int, http.Header, error) {
+	url := c.baseURL + path
+
+	if timeout := time.Duration(c.config.Server.Timeout) * time.Second; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var reqBody io.Reader
+	if bodyBytes != nil {
+		reqBody = bytes.NewReader(bodyBytes)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("erro ao criar request: %w", err)
 	}
 
 	// Headers
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", contentType)
 
 	// Auth
 	if c.token != "" {
@@ -84,13 +194,13 @@ func (c *APIClientV2) doRequest(method, path string, body interface{}) (map[stri
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return nil, 0, fmt.Errorf("erro ao executar request: %w", err)
+		return nil, 0, nil, fmt.Errorf("erro ao executar request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, resp.StatusCode, fmt.Errorf("erro ao ler response: %w", err)
+		return nil, resp.StatusCode, resp.Header, fmt.Errorf("erro ao ler response: %w", err)
 	}
 
 	var result map[string]interface{}
@@ -105,11 +215,49 @@ func (c *APIClientV2) doRequest(method, path string, body interface{}) (map[stri
 		}
 	}
 
-	return result, resp.StatusCode, nil
+	return result, resp.StatusCode, resp.Header, nil
+}
+
+// describeFailure resume a falha de uma tentativa para log
+func describeFailure(status int, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return fmt.Sprintf("status %d", status)
+}
+
+// APIError é uma resposta de erro do backend; status e corpo vão para o relatório (-report)
+type APIError struct {
+	Status  int
+	Message string
+	Body    string
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("status %d: %s", e.Status, e.Message)
+	}
+	return fmt.Sprintf("status %d", e.Status)
+}
+
+// newAPIError monta o erro a partir da resposta já decodificada por doRequestOnce
+func newAPIError(status int, resp map[string]interface{}) *APIError {
+	apiErr := &APIError{Status: status}
+	if msg, ok := resp["message"].(string); ok {
+		apiErr.Message = msg
+	}
+
+	if raw, ok := resp["raw"].(string); ok && len(resp) == 1 {
+		apiErr.Body = raw
+	} else if resp != nil {
+		body, _ := json.Marshal(resp)
+		apiErr.Body = string(body)
+	}
+	return apiErr
 }
 
 // CreateOrganization cria organização ou faz login se existir
-func (c *APIClientV2) CreateOrganization(name, email, password string) (orgID, projID string, err error) {
+func (c *APIClientV2) CreateOrganization(ctx context.Context, name, email, password string) (orgID, projID string, err error) {
 	// 1. Tentar criar organização
 	payload := map[string]string{
 		"name":     name,
@@ -117,7 +265,7 @@ func (c *APIClientV2) CreateOrganization(name, email, password string) (orgID, p
 		"password": password,
 	}
 
-	resp, status, err := c.doRequest("POST", "/create-organization", payload)
+	resp, status, err := c.doRequest(ctx, "POST", "/create-organization", payload)
 	if err != nil {
 		return "", "", err
 	}
@@ -125,14 +273,11 @@ func (c *APIClientV2) CreateOrganization(name, email, password string) (orgID, p
 	// Se status 409, organização já existe - fazer login
 	if status == 409 {
 		c.logger.Info("Organização já existe, fazendo login...")
-		return c.LoginAndGetIDs(email, password)
+		return c.LoginAndGetIDs(ctx, email, password)
 	}
 
 	if status != 200 && status != 201 {
-		if errMsg, ok := resp["message"].(string); ok {
-			return "", "", fmt.Errorf("status %d: %s", status, errMsg)
-		}
-		return "", "", fmt.Errorf("status %d", status)
+		return "", "", newAPIError(status, resp)
 	}
 
 	// Extrair IDs da resposta
@@ -140,22 +285,19 @@ func (c *APIClientV2) CreateOrganization(name, email, password string) (orgID, p
 }
 
 // LoginAndGetIDs faz login e extrai IDs
-func (c *APIClientV2) LoginAndGetIDs(email, password string) (orgID, projID string, err error) {
+func (c *APIClientV2) LoginAndGetIDs(ctx context.Context, email, password string) (orgID, projID string, err error) {
 	payload := map[string]string{
 		"email":    email,
 		"password": password,
 	}
 
-	resp, status, err := c.doRequest("POST", "/login", payload)
+	resp, status, err := c.doRequest(ctx, "POST", "/login", payload)
 	if err != nil {
 		return "", "", err
 	}
 
 	if status != 200 {
-		if errMsg, ok := resp["message"].(string); ok {
-			return "", "", fmt.Errorf("status %d: %s", status, errMsg)
-		}
-		return "", "", fmt.Errorf("status %d", status)
+		return "", "", newAPIError(status, resp)
 	}
 
 	// extractOrgAndProjID já extrai o token também
@@ -163,22 +305,19 @@ func (c *APIClientV2) LoginAndGetIDs(email, password string) (orgID, projID stri
 }
 
 // LoginAndGetIDsForOrg faz login e busca IDs de uma organização específica
-func (c *APIClientV2) LoginAndGetIDsForOrg(email, password, orgName string) (orgID, projID string, err error) {
+func (c *APIClientV2) LoginAndGetIDsForOrg(ctx context.Context, email, password, orgName string) (orgID, projID string, err error) {
 	payload := map[string]string{
 		"email":    email,
 		"password": password,
 	}
 
-	resp, status, err := c.doRequest("POST", "/login", payload)
+	resp, status, err := c.doRequest(ctx, "POST", "/login", payload)
 	if err != nil {
 		return "", "", err
 	}
 
 	if status != 200 {
-		if errMsg, ok := resp["message"].(string); ok {
-			return "", "", fmt.Errorf("status %d: %s", status, errMsg)
-		}
-		return "", "", fmt.Errorf("status %d", status)
+		return "", "", newAPIError(status, resp)
 	}
 
 	// extractOrgAndProjID com nome da organização para buscar o projeto correto
@@ -331,14 +470,14 @@ func (c *APIClientV2) extractOrgAndProjID(resp map[string]interface{}, orgName s
 }
 
 // CreateMenu cria menu
-func (c *APIClientV2) CreateMenu(name string, order int) (uuid.UUID, error) {
+func (c *APIClientV2) CreateMenu(ctx context.Context, name string, order int) (uuid.UUID, error) {
 	payload := map[string]interface{}{
 		"name":   name,
 		"order":  order,
 		"active": true,
 	}
 
-	resp, status, err := c.doRequest("POST", "/menu", payload)
+	resp, status, err := c.doCreate(ctx, "/menu", payload, func() (uuid.UUID, error) { return c.GetMenuByName(ctx, name) })
 	if err != nil {
 		return uuid.Nil, err
 	}
@@ -348,14 +487,14 @@ func (c *APIClientV2) CreateMenu(name string, order int) (uuid.UUID, error) {
 	}
 
 	if status != 200 && status != 201 {
-		return uuid.Nil, fmt.Errorf("status %d", status)
+		return uuid.Nil, newAPIError(status, resp)
 	}
 
 	return extractIDFromResponse(resp)
 }
 
 // CreateCategory cria categoria
-func (c *APIClientV2) CreateCategory(menuID string, name string, order int) (uuid.UUID, error) {
+func (c *APIClientV2) CreateCategory(ctx context.Context, menuID string, name string, order int) (uuid.UUID, error) {
 	payload := map[string]interface{}{
 		"menu_id": menuID,
 		"name":    name,
@@ -363,7 +502,7 @@ func (c *APIClientV2) CreateCategory(menuID string, name string, order int) (uui
 		"active":  true,
 	}
 
-	resp, status, err := c.doRequest("POST", "/category", payload)
+	resp, status, err := c.doCreate(ctx, "/category", payload, func() (uuid.UUID, error) { return c.GetCategoryByName(ctx, name) })
 	if err != nil {
 		return uuid.Nil, err
 	}
@@ -373,21 +512,21 @@ func (c *APIClientV2) CreateCategory(menuID string, name string, order int) (uui
 	}
 
 	if status != 200 && status != 201 {
-		return uuid.Nil, fmt.Errorf("status %d", status)
+		return uuid.Nil, newAPIError(status, resp)
 	}
 
 	return extractIDFromResponse(resp)
 }
 
 // CreateSubcategory cria subcategoria
-func (c *APIClientV2) CreateSubcategory(catID string, name string) (uuid.UUID, error) {
+func (c *APIClientV2) CreateSubcategory(ctx context.Context, catID string, name string) (uuid.UUID, error) {
 	payload := map[string]interface{}{
 		"category_id": catID,
 		"name":        name,
 		"active":      true,
 	}
 
-	resp, status, err := c.doRequest("POST", "/subcategory", payload)
+	resp, status, err := c.doCreate(ctx, "/subcategory", payload, func() (uuid.UUID, error) { return c.GetSubcategoryByName(ctx, name) })
 	if err != nil {
 		return uuid.Nil, err
 	}
@@ -397,21 +536,21 @@ func (c *APIClientV2) CreateSubcategory(catID string, name string) (uuid.UUID, e
 	}
 
 	if status != 200 && status != 201 {
-		return uuid.Nil, fmt.Errorf("status %d", status)
+		return uuid.Nil, newAPIError(status, resp)
 	}
 
 	return extractIDFromResponse(resp)
 }
 
 // CreateEnvironment cria ambiente
-func (c *APIClientV2) CreateEnvironment(name string, capacity int) (uuid.UUID, error) {
+func (c *APIClientV2) CreateEnvironment(ctx context.Context, name string, capacity int) (uuid.UUID, error) {
 	payload := map[string]interface{}{
 		"name":     name,
 		"capacity": capacity,
 		"active":   true,
 	}
 
-	resp, status, err := c.doRequest("POST", "/environment", payload)
+	resp, status, err := c.doCreate(ctx, "/environment", payload, func() (uuid.UUID, error) { return c.GetEnvironmentByName(ctx, name) })
 	if err != nil {
 		return uuid.Nil, err
 	}
@@ -421,14 +560,14 @@ func (c *APIClientV2) CreateEnvironment(name string, capacity int) (uuid.UUID, e
 	}
 
 	if status != 200 && status != 201 {
-		return uuid.Nil, fmt.Errorf("status %d", status)
+		return uuid.Nil, newAPIError(status, resp)
 	}
 
 	return extractIDFromResponse(resp)
 }
 
 // CreateTable cria mesa
-func (c *APIClientV2) CreateTable(number int, capacity int, envID *string, status string) (uuid.UUID, error) {
+func (c *APIClientV2) CreateTable(ctx context.Context, number int, capacity int, envID *string, status string) (uuid.UUID, error) {
 	payload := map[string]interface{}{
 		"number":   number,
 		"capacity": capacity,
@@ -440,7 +579,7 @@ func (c *APIClientV2) CreateTable(number int, capacity int, envID *string, statu
 		payload["environment_id"] = *envID
 	}
 
-	resp, respStatus, err := c.doRequest("POST", "/table", payload)
+	resp, respStatus, err := c.doCreate(ctx, "/table", payload, func() (uuid.UUID, error) { return c.GetTableByNumber(ctx, number) })
 	if err != nil {
 		return uuid.Nil, err
 	}
@@ -450,21 +589,35 @@ func (c *APIClientV2) CreateTable(number int, capacity int, envID *string, statu
 	}
 
 	if respStatus != 200 && respStatus != 201 {
-		return uuid.Nil, fmt.Errorf("status %d", respStatus)
+		return uuid.Nil, newAPIError(respStatus, resp)
 	}
 
 	return extractIDFromResponse(resp)
 }
 
-// CreateProduct cria produto
-func (c *APIClientV2) CreateProduct(name string, productType string, priceNormal float64, prepTime int, menuID, categoryID, subcategoryID *string, wineData *WineData) (uuid.UUID, error) {
+// CreateProduct cria produto com todos os campos do seed
+func (c *APIClientV2) CreateProduct(ctx context.Context, prod ProductData, menuID, categoryID, subcategoryID *string) (uuid.UUID, error) {
+	return c.CreateProductFromPayload(ctx, BuildProductPayload(prod, menuID, categoryID, subcategoryID))
+}
+
+// BuildProductPayload monta o payload de produto usado tanto na criação individual quanto no bulk
+func BuildProductPayload(prod ProductData, menuID, categoryID, subcategoryID *string) map[string]interface{} {
+	active := true
+	if prod.Active != nil {
+		active = *prod.Active
+	}
+
 	payload := map[string]interface{}{
-		"name":              name,
-		"type":              productType,
-		"price_normal":      priceNormal,
-		"prep_time_minutes": prepTime,
-		"active":            true,
-		"order":             0,
+		"name":              prod.Name,
+		"type":              prod.Type,
+		"price_normal":      prod.PriceNormal,
+		"prep_time_minutes": prod.PrepTimeMinutes,
+		"active":            active,
+		"order":             prod.Order,
+	}
+
+	if prod.Description != "" {
+		payload["description"] = prod.Description
 	}
 
 	if menuID != nil && *menuID != "" {
@@ -479,38 +632,53 @@ func (c *APIClientV2) CreateProduct(name string, productType string, priceNormal
 		payload["subcategory_id"] = *subcategoryID
 	}
 
-	// Adicionar campos específicos de vinho se fornecidos
-	if wineData != nil {
-		if wineData.Vintage != "" {
-			payload["vintage"] = wineData.Vintage
-		}
-		if wineData.Country != "" {
-			payload["country"] = wineData.Country
-		}
-		if wineData.Region != "" {
-			payload["region"] = wineData.Region
+	// Preços opcionais
+	if prod.PricePromo > 0 {
+		payload["price_promo"] = prod.PricePromo
+	}
+	if prod.PriceGlass > 0 {
+		payload["price_glass"] = prod.PriceGlass
+	}
+	if prod.PriceBottle > 0 {
+		payload["price_bottle"] = prod.PriceBottle
+	}
+	if prod.PriceHalfBottle > 0 {
+		payload["price_half_bottle"] = prod.PriceHalfBottle
+	}
+
+	// Atributos de vinho, para qualquer produto que os declare
+	if prod.HasWineAttributes() {
+		if prod.Vintage != "" {
+			payload["vintage"] = prod.Vintage
 		}
-		if wineData.Winery != "" {
-			payload["winery"] = wineData.Winery
+		if prod.Country != "" {
+			payload["country"] = prod.Country
 		}
-		if wineData.WineType != "" {
-			payload["wine_type"] = wineData.WineType
+		if prod.Region != "" {
+			payload["region"] = prod.Region
 		}
-		if wineData.Volume > 0 {
-			payload["volume"] = wineData.Volume
+		if prod.Winery != "" {
+			payload["winery"] = prod.Winery
 		}
-		if wineData.AlcoholContent > 0 {
-			payload["alcohol_content"] = wineData.AlcoholContent
+		if prod.WineType != "" {
+			payload["wine_type"] = prod.WineType
 		}
-		if wineData.PriceBottle > 0 {
-			payload["price_bottle"] = wineData.PriceBottle
+		if prod.Volume > 0 {
+			payload["volume"] = prod.Volume
 		}
-		if wineData.PriceGlass > 0 {
-			payload["price_glass"] = wineData.PriceGlass
+		if prod.AlcoholContent > 0 {
+			payload["alcohol_content"] = prod.AlcoholContent
 		}
 	}
 
-	resp, status, err := c.doRequest("POST", "/product", payload)
+	return payload
+}
+
+// CreateProductFromPayload cria produto a partir de um payload já montado
+func (c *APIClientV2) CreateProductFromPayload(ctx context.Context, payload map[string]interface{}) (uuid.UUID, error) {
+	name, _ := payload["name"].(string)
+
+	resp, status, err := c.doCreate(ctx, "/product", payload, func() (uuid.UUID, error) { return c.GetProductByName(ctx, name) })
 	if err != nil {
 		return uuid.Nil, err
 	}
@@ -520,184 +688,44 @@ func (c *APIClientV2) CreateProduct(name string, productType string, priceNormal
 	}
 
 	if status != 200 && status != 201 {
-		return uuid.Nil, fmt.Errorf("status %d", status)
+		return uuid.Nil, newAPIError(status, resp)
 	}
 
 	return extractIDFromResponse(resp)
 }
 
-// WineData contém dados específicos de vinhos
-type WineData struct {
-	Vintage        string
-	Country        string
-	Region         string
-	Winery         string
-	WineType       string
-	Volume         int
-	AlcoholContent float64
-	PriceBottle    float64
-	PriceGlass     float64
-}
-
 // GetMenuByName busca um menu pelo nome (para evitar duplicatas)
-func (c *APIClientV2) GetMenuByName(name string) (uuid.UUID, error) {
-	resp, status, err := c.doRequest("GET", "/menu", nil)
-	if err != nil {
-		return uuid.Nil, err
-	}
-
-	if status != 200 {
-		return uuid.Nil, fmt.Errorf("status %d", status)
-	}
-
-	// Procurar menu com esse nome
-	if menus, ok := resp["data"].([]interface{}); ok {
-		for _, m := range menus {
-			if menu, ok := m.(map[string]interface{}); ok {
-				if menuName, ok := menu["name"].(string); ok && menuName == name {
-					if id, ok := menu["id"].(string); ok {
-						return uuid.Parse(id)
-					}
-				}
-			}
-		}
-	}
-
-	return uuid.Nil, fmt.Errorf("não encontrado")
+func (c *APIClientV2) GetMenuByName(ctx context.Context, name string) (uuid.UUID, error) {
+	return c.findByField(ctx, "/menu", "name", name)
 }
 
 // GetCategoryByName busca uma categoria pelo nome
-func (c *APIClientV2) GetCategoryByName(name string) (uuid.UUID, error) {
-	resp, status, err := c.doRequest("GET", "/category", nil)
-	if err != nil {
-		return uuid.Nil, err
-	}
-
-	if status != 200 {
-		return uuid.Nil, fmt.Errorf("status %d", status)
-	}
-
-	if categories, ok := resp["data"].([]interface{}); ok {
-		for _, cat := range categories {
-			if category, ok := cat.(map[string]interface{}); ok {
-				if catName, ok := category["name"].(string); ok && catName == name {
-					if id, ok := category["id"].(string); ok {
-						return uuid.Parse(id)
-					}
-				}
-			}
-		}
-	}
-
-	return uuid.Nil, fmt.Errorf("não encontrado")
+func (c *APIClientV2) GetCategoryByName(ctx context.Context, name string) (uuid.UUID, error) {
+	return c.findByField(ctx, "/category", "name", name)
 }
 
 // GetSubcategoryByName busca uma subcategoria pelo nome
-func (c *APIClientV2) GetSubcategoryByName(name string) (uuid.UUID, error) {
-	resp, status, err := c.doRequest("GET", "/subcategory", nil)
-	if err != nil {
-		return uuid.Nil, err
-	}
-
-	if status != 200 {
-		return uuid.Nil, fmt.Errorf("status %d", status)
-	}
-
-	if subcategories, ok := resp["data"].([]interface{}); ok {
-		for _, subcat := range subcategories {
-			if subcategory, ok := subcat.(map[string]interface{}); ok {
-				if subcatName, ok := subcategory["name"].(string); ok && subcatName == name {
-					if id, ok := subcategory["id"].(string); ok {
-						return uuid.Parse(id)
-					}
-				}
-			}
-		}
-	}
-
-	return uuid.Nil, fmt.Errorf("não encontrado")
+func (c *APIClientV2) GetSubcategoryByName(ctx context.Context, name string) (uuid.UUID, error) {
+	return c.findByField(ctx, "/subcategory", "name", name)
 }
 
 // GetProductByName busca um produto pelo nome
-func (c *APIClientV2) GetProductByName(name string) (uuid.UUID, error) {
-	resp, status, err := c.doRequest("GET", "/product", nil)
-	if err != nil {
-		return uuid.Nil, err
-	}
-
-	if status != 200 {
-		return uuid.Nil, fmt.Errorf("status %d", status)
-	}
-
-	if products, ok := resp["data"].([]interface{}); ok {
-		for _, prod := range products {
-			if product, ok := prod.(map[string]interface{}); ok {
-				if prodName, ok := product["name"].(string); ok && prodName == name {
-					if id, ok := product["id"].(string); ok {
-						return uuid.Parse(id)
-					}
-				}
-			}
-		}
-	}
-
-	return uuid.Nil, fmt.Errorf("não encontrado")
+func (c *APIClientV2) GetProductByName(ctx context.Context, name string) (uuid.UUID, error) {
+	return c.findByField(ctx, "/product", "name", name)
 }
 
 // GetEnvironmentByName busca um ambiente pelo nome
-func (c *APIClientV2) GetEnvironmentByName(name string) (uuid.UUID, error) {
-	resp, status, err := c.doRequest("GET", "/environment", nil)
-	if err != nil {
-		return uuid.Nil, err
-	}
-
-	if status != 200 {
-		return uuid.Nil, fmt.Errorf("status %d", status)
-	}
-
-	if environments, ok := resp["data"].([]interface{}); ok {
-		for _, env := range environments {
-			if environment, ok := env.(map[string]interface{}); ok {
-				if envName, ok := environment["name"].(string); ok && envName == name {
-					if id, ok := environment["id"].(string); ok {
-						return uuid.Parse(id)
-					}
-				}
-			}
-		}
-	}
-
-	return uuid.Nil, fmt.Errorf("não encontrado")
+func (c *APIClientV2) GetEnvironmentByName(ctx context.Context, name string) (uuid.UUID, error) {
+	return c.findByField(ctx, "/environment", "name", name)
 }
 
 // GetTableByNumber busca uma mesa pelo número
-func (c *APIClientV2) GetTableByNumber(number int) (uuid.UUID, error) {
-	resp, status, err := c.doRequest("GET", "/table", nil)
-	if err != nil {
-		return uuid.Nil, err
-	}
-
-	if status != 200 {
-		return uuid.Nil, fmt.Errorf("status %d", status)
-	}
-
-	if tables, ok := resp["data"].([]interface{}); ok {
-		for _, tbl := range tables {
-			if table, ok := tbl.(map[string]interface{}); ok {
-				if tblNum, ok := table["number"].(float64); ok && int(tblNum) == number {
-					if id, ok := table["id"].(string); ok {
-						return uuid.Parse(id)
-					}
-				}
-			}
-		}
-	}
-
-	return uuid.Nil, fmt.Errorf("não encontrado")
+func (c *APIClientV2) GetTableByNumber(ctx context.Context, number int) (uuid.UUID, error) {
+	return c.findByField(ctx, "/table", "number", strconv.Itoa(number))
 }
 
 // CreateUser cria um novo usuário
-func (c *APIClientV2) CreateUser(name, email, password, role string, permissions []string) (uuid.UUID, error) {
+func (c *APIClientV2) CreateUser(ctx context.Context, name, email, password, role string, permissions []string) (uuid.UUID, error) {
 	payload := map[string]interface{}{
 		"name":     name,
 		"email":    email,
@@ -710,7 +738,7 @@ func (c *APIClientV2) CreateUser(name, email, password, role string, permissions
 		payload["permissions"] = permissions
 	}
 
-	resp, status, err := c.doRequest("POST", "/user", payload)
+	resp, status, err := c.doCreate(ctx, "/user", payload, func() (uuid.UUID, error) { return c.GetUserByEmail(ctx, email) })
 	if err != nil {
 		return uuid.Nil, err
 	}
@@ -720,40 +748,19 @@ func (c *APIClientV2) CreateUser(name, email, password, role string, permissions
 	}
 
 	if status != 200 && status != 201 {
-		return uuid.Nil, fmt.Errorf("status %d", status)
+		return uuid.Nil, newAPIError(status, resp)
 	}
 
 	return extractIDFromResponse(resp)
 }
 
 // GetUserByEmail busca um usuário pelo email
-func (c *APIClientV2) GetUserByEmail(email string) (uuid.UUID, error) {
-	resp, status, err := c.doRequest("GET", "/user", nil)
-	if err != nil {
-		return uuid.Nil, err
-	}
-
-	if status != 200 {
-		return uuid.Nil, fmt.Errorf("status %d", status)
-	}
-
-	if users, ok := resp["data"].([]interface{}); ok {
-		for _, u := range users {
-			if user, ok := u.(map[string]interface{}); ok {
-				if userEmail, ok := user["email"].(string); ok && userEmail == email {
-					if id, ok := user["id"].(string); ok {
-						return uuid.Parse(id)
-					}
-				}
-			}
-		}
-	}
-
-	return uuid.Nil, fmt.Errorf("não encontrado")
+func (c *APIClientV2) GetUserByEmail(ctx context.Context, email string) (uuid.UUID, error) {
+	return c.findByField(ctx, "/user", "email", email)
 }
 
 // CreateCustomer cria um novo cliente
-func (c *APIClientV2) CreateCustomer(name, email, phone, birthDate, notes string) (uuid.UUID, error) {
+func (c *APIClientV2) CreateCustomer(ctx context.Context, name, email, phone, birthDate, notes string) (uuid.UUID, error) {
 	payload := map[string]interface{}{
 		"name":   name,
 		"email":  email,
@@ -769,7 +776,7 @@ func (c *APIClientV2) CreateCustomer(name, email, phone, birthDate, notes string
 		payload["notes"] = notes
 	}
 
-	resp, status, err := c.doRequest("POST", "/customer", payload)
+	resp, status, err := c.doCreate(ctx, "/customer", payload, func() (uuid.UUID, error) { return c.GetCustomerByEmail(ctx, email) })
 	if err != nil {
 		return uuid.Nil, err
 	}
@@ -779,40 +786,19 @@ func (c *APIClientV2) CreateCustomer(name, email, phone, birthDate, notes string
 	}
 
 	if status != 200 && status != 201 {
-		return uuid.Nil, fmt.Errorf("status %d", status)
+		return uuid.Nil, newAPIError(status, resp)
 	}
 
 	return extractIDFromResponse(resp)
 }
 
 // GetCustomerByEmail busca um cliente pelo email
-func (c *APIClientV2) GetCustomerByEmail(email string) (uuid.UUID, error) {
-	resp, status, err := c.doRequest("GET", "/customer", nil)
-	if err != nil {
-		return uuid.Nil, err
-	}
-
-	if status != 200 {
-		return uuid.Nil, fmt.Errorf("status %d", status)
-	}
-
-	if customers, ok := resp["data"].([]interface{}); ok {
-		for _, cust := range customers {
-			if customer, ok := cust.(map[string]interface{}); ok {
-				if custEmail, ok := customer["email"].(string); ok && custEmail == email {
-					if id, ok := customer["id"].(string); ok {
-						return uuid.Parse(id)
-					}
-				}
-			}
-		}
-	}
-
-	return uuid.Nil, fmt.Errorf("não encontrado")
+func (c *APIClientV2) GetCustomerByEmail(ctx context.Context, email string) (uuid.UUID, error) {
+	return c.findByField(ctx, "/customer", "email", email)
 }
 
 // CreateReservation cria uma nova reserva
-func (c *APIClientV2) CreateReservation(customerID, tableID string, dateTime string, partySize int, notes, status, confirmationKey string) (uuid.UUID, error) {
+func (c *APIClientV2) CreateReservation(ctx context.Context, customerID, tableID string, dateTime string, partySize int, notes, status, confirmationKey string) (uuid.UUID, error) {
 	payload := map[string]interface{}{
 		"customer_id": customerID,
 		"table_id":    tableID,
@@ -829,7 +815,13 @@ func (c *APIClientV2) CreateReservation(customerID, tableID string, dateTime str
 		payload["confirmation_key"] = confirmationKey
 	}
 
-	resp, respStatus, err := c.doRequest("POST", "/reservation", payload)
+	// Sem confirmation_key não há como confirmar se a reserva foi criada, então não há retry ambíguo
+	var lookup func() (uuid.UUID, error)
+	if confirmationKey != "" {
+		lookup = func() (uuid.UUID, error) { return c.GetReservationByConfirmationKey(ctx, confirmationKey) }
+	}
+
+	resp, respStatus, err := c.doCreate(ctx, "/reservation", payload, lookup)
 	if err != nil {
 		return uuid.Nil, err
 	}
@@ -839,40 +831,19 @@ func (c *APIClientV2) CreateReservation(customerID, tableID string, dateTime str
 	}
 
 	if respStatus != 200 && respStatus != 201 {
-		return uuid.Nil, fmt.Errorf("status %d", respStatus)
+		return uuid.Nil, newAPIError(respStatus, resp)
 	}
 
 	return extractIDFromResponse(resp)
 }
 
 // GetReservationByConfirmationKey busca uma reserva pela chave de confirmação
-func (c *APIClientV2) GetReservationByConfirmationKey(confirmationKey string) (uuid.UUID, error) {
-	resp, status, err := c.doRequest("GET", "/reservation", nil)
-	if err != nil {
-		return uuid.Nil, err
-	}
-
-	if status != 200 {
-		return uuid.Nil, fmt.Errorf("status %d", status)
-	}
-
-	if reservations, ok := resp["data"].([]interface{}); ok {
-		for _, res := range reservations {
-			if reservation, ok := res.(map[string]interface{}); ok {
-				if key, ok := reservation["confirmation_key"].(string); ok && key == confirmationKey {
-					if id, ok := reservation["id"].(string); ok {
-						return uuid.Parse(id)
-					}
-				}
-			}
-		}
-	}
-
-	return uuid.Nil, fmt.Errorf("não encontrado")
+func (c *APIClientV2) GetReservationByConfirmationKey(ctx context.Context, confirmationKey string) (uuid.UUID, error) {
+	return c.findByField(ctx, "/reservation", "confirmation_key", confirmationKey)
 }
 
 // CreateTag cria uma nova tag
-func (c *APIClientV2) CreateTag(name, color, description, entityType string) (uuid.UUID, error) {
+func (c *APIClientV2) CreateTag(ctx context.Context, name, color, description, entityType string) (uuid.UUID, error) {
 	payload := map[string]interface{}{
 		"name":   name,
 		"active": true,
@@ -890,7 +861,7 @@ func (c *APIClientV2) CreateTag(name, color, description, entityType string) (uu
 		payload["entity_type"] = entityType
 	}
 
-	resp, status, err := c.doRequest("POST", "/tag", payload)
+	resp, status, err := c.doCreate(ctx, "/tag", payload, func() (uuid.UUID, error) { return c.GetTagByName(ctx, name) })
 	if err != nil {
 		return uuid.Nil, err
 	}
@@ -900,40 +871,19 @@ func (c *APIClientV2) CreateTag(name, color, description, entityType string) (uu
 	}
 
 	if status != 200 && status != 201 {
-		return uuid.Nil, fmt.Errorf("status %d", status)
+		return uuid.Nil, newAPIError(status, resp)
 	}
 
 	return extractIDFromResponse(resp)
 }
 
 // GetTagByName busca uma tag pelo nome
-func (c *APIClientV2) GetTagByName(name string) (uuid.UUID, error) {
-	resp, status, err := c.doRequest("GET", "/tag", nil)
-	if err != nil {
-		return uuid.Nil, err
-	}
-
-	if status != 200 {
-		return uuid.Nil, fmt.Errorf("status %d", status)
-	}
-
-	if tags, ok := resp["data"].([]interface{}); ok {
-		for _, t := range tags {
-			if tag, ok := t.(map[string]interface{}); ok {
-				if tagName, ok := tag["name"].(string); ok && tagName == name {
-					if id, ok := tag["id"].(string); ok {
-						return uuid.Parse(id)
-					}
-				}
-			}
-		}
-	}
-
-	return uuid.Nil, fmt.Errorf("não encontrado")
+func (c *APIClientV2) GetTagByName(ctx context.Context, name string) (uuid.UUID, error) {
+	return c.findByField(ctx, "/tag", "name", name)
 }
 
 // AddCategoryToSubcategory vincula subcategoria a uma categoria (relacionamento N:M)
-func (c *APIClientV2) AddCategoryToSubcategory(subcatID, catID string) error {
+func (c *APIClientV2) AddCategoryToSubcategory(ctx context.Context, subcatID, catID string) error {
 	path := fmt.Sprintf("/subcategory/%s/category/%s", subcatID, catID)
 
 	// Backend espera JSON body com category_id (mesmo com path param)
@@ -941,7 +891,7 @@ func (c *APIClientV2) AddCategoryToSubcategory(subcatID, catID string) error {
 		"category_id": catID,
 	}
 
-	_, status, err := c.doRequest("POST", path, payload)
+	resp, status, err := c.doRequest(ctx, "POST", path, payload)
 	if err != nil {
 		return err
 	}
@@ -951,22 +901,78 @@ func (c *APIClientV2) AddCategoryToSubcategory(subcatID, catID string) error {
 	}
 
 	if status != 200 && status != 201 {
-		return fmt.Errorf("status %d", status)
+		return newAPIError(status, resp)
+	}
+
+	return nil
+}
+
+// GetSubcategoryCategoryIDs lista as categorias vinculadas à subcategoria.
+// A rota não é coberta pelos testes do backend, então a resposta é lida de forma estrita:
+// qualquer formato diferente de {"data": [{"id": ...}]} é erro, nunca lista vazia.
+func (c *APIClientV2) GetSubcategoryCategoryIDs(ctx context.Context, subcatID string) ([]string, error) {
+	resp, status, err := c.doRequest(ctx, "GET", fmt.Sprintf("/subcategory/%s/categories", subcatID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, newAPIError(status, resp)
+	}
+
+	data, ok := resp["data"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("resposta sem lista em data")
+	}
+
+	ids := make([]string, 0, len(data))
+	for i, d := range data {
+		item, _ := d.(map[string]interface{})
+		id, _ := item["id"].(string)
+		if id == "" {
+			return nil, fmt.Errorf("data[%d] sem id", i)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// RemoveCategoryFromSubcategory desfaz o vínculo entre subcategoria e categoria
+func (c *APIClientV2) RemoveCategoryFromSubcategory(ctx context.Context, subcatID, catID string) error {
+	path := fmt.Sprintf("/subcategory/%s/category/%s", subcatID, catID)
+
+	resp, status, err := c.doRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	if status == 404 {
+		return nil // Vínculo já não existe
+	}
+
+	if status != 200 && status != 204 {
+		return newAPIError(status, resp)
 	}
 
 	return nil
 }
 
 // AddTagToProduct vincula tag a um produto (relacionamento N:M)
-func (c *APIClientV2) AddTagToProduct(productID, tagID string) error {
-	path := fmt.Sprintf("/product/%s/tag/%s", productID, tagID)
+func (c *APIClientV2) AddTagToProduct(ctx context.Context, productID, tagID string) error {
+	return c.AddTagToEntity(ctx, "product", productID, tagID)
+}
+
+// AddTagToEntity vincula tag a qualquer entidade que expõe /{entity}/{id}/tag/{tagId}
+// (product, menu, customer, table, reservation)
+func (c *APIClientV2) AddTagToEntity(ctx context.Context, entityType, entityID, tagID string) error {
+	path := fmt.Sprintf("/%s/%s/tag/%s", entityType, entityID, tagID)
 
 	// Backend espera JSON body com tag_id (mesmo com path param)
 	payload := map[string]interface{}{
 		"tag_id": tagID,
 	}
 
-	_, status, err := c.doRequest("POST", path, payload)
+	resp, status, err := c.doRequest(ctx, "POST", path, payload)
 	if err != nil {
 		return err
 	}
@@ -976,14 +982,14 @@ func (c *APIClientV2) AddTagToProduct(productID, tagID string) error {
 	}
 
 	if status != 200 && status != 201 {
-		return fmt.Errorf("status %d", status)
+		return newAPIError(status, resp)
 	}
 
 	return nil
 }
 
 // CreateSettings cria configurações do projeto
-func (c *APIClientV2) CreateSettings(settings *SettingsData) error {
+func (c *APIClientV2) CreateSettings(ctx context.Context, settings *SettingsData) error {
 	payload := map[string]interface{}{}
 
 	if settings.ReservationMinAdvanceHours > 0 {
@@ -1011,28 +1017,28 @@ func (c *APIClientV2) CreateSettings(settings *SettingsData) error {
 		payload["timezone"] = settings.Timezone
 	}
 
-	_, status, err := c.doRequest("POST", "/settings", payload)
+	resp, status, err := c.doRequest(ctx, "POST", "/settings", payload)
 	if err != nil {
 		return err
 	}
 
 	if status == 409 {
 		// Settings já existe, tentar atualizar
-		_, status, err = c.doRequest("PUT", "/settings", payload)
+		resp, status, err = c.doRequest(ctx, "PUT", "/settings", payload)
 		if err != nil {
 			return err
 		}
 	}
 
 	if status != 200 && status != 201 {
-		return fmt.Errorf("status %d", status)
+		return newAPIError(status, resp)
 	}
 
 	return nil
 }
 
 // CreateNotificationTemplate cria template de notificação
-func (c *APIClientV2) CreateNotificationTemplate(template *NotificationTemplateData) (uuid.UUID, error) {
+func (c *APIClientV2) CreateNotificationTemplate(ctx context.Context, template *NotificationTemplateData) (uuid.UUID, error) {
 	payload := map[string]interface{}{
 		"name":    template.Name,
 		"channel": template.Channel,
@@ -1044,7 +1050,7 @@ func (c *APIClientV2) CreateNotificationTemplate(template *NotificationTemplateD
 		payload["subject"] = template.Subject
 	}
 
-	resp, status, err := c.doRequest("POST", "/notification-template", payload)
+	resp, status, err := c.doCreate(ctx, "/notification-template", payload, func() (uuid.UUID, error) { return c.GetNotificationTemplateByName(ctx, template.Name) })
 	if err != nil {
 		return uuid.Nil, err
 	}
@@ -1054,91 +1060,244 @@ func (c *APIClientV2) CreateNotificationTemplate(template *NotificationTemplateD
 	}
 
 	if status != 200 && status != 201 {
-		return uuid.Nil, fmt.Errorf("status %d", status)
+		return uuid.Nil, newAPIError(status, resp)
 	}
 
 	return extractIDFromResponse(resp)
 }
 
 // CreateThemeCustomization cria customização de tema
-func (c *APIClientV2) CreateThemeCustomization(theme *ThemeCustomizationData) error {
-	// Converter cores single para Light + Dark
+func (c *APIClientV2) CreateThemeCustomization(ctx context.Context, theme *ThemeCustomizationData) error {
+	light := theme.LightPalette()
+	dark := theme.DarkPalette()
+
 	payload := map[string]interface{}{
 		// Light mode
-		"primary_color_light":          theme.PrimaryColor,
-		"secondary_color_light":        theme.SecondaryColor,
-		"background_color_light":       theme.BackgroundColor,
-		"card_background_color_light":  theme.CardBackgroundColor,
-		"text_color_light":             theme.TextColor,
-		"text_secondary_color_light":   theme.TextSecondaryColor,
-		"accent_color_light":           theme.AccentColor,
-		"success_color_light":          theme.SuccessColor,
-		"error_color_light":            theme.ErrorColor,
-		"warning_color_light":          theme.WarningColor,
-		"info_color_light":             theme.InfoColor,
-
-		// Dark mode (usando mesmas cores como fallback)
-		"primary_color_dark":           theme.PrimaryColor,
-		"secondary_color_dark":         theme.SecondaryColor,
-		"background_color_dark":        "#1a1a1a",  // Dark background
-		"card_background_color_dark":   "#2d2d2d",  // Dark card
-		"text_color_dark":              "#f0f0f0",  // Light text
-		"text_secondary_color_dark":    "#b0b0b0",  // Gray text
-		"accent_color_dark":            theme.AccentColor,
-		"success_color_dark":           theme.SuccessColor,
-		"error_color_dark":             theme.ErrorColor,
-		"warning_color_dark":           theme.WarningColor,
-		"info_color_dark":              theme.InfoColor,
-
-		"disabled_opacity":             theme.DisabledOpacity,
-		"shadow_intensity":             theme.ShadowIntensity,
-		"is_active":                    theme.IsActive,
-	}
-
-	_, status, err := c.doRequest("POST", "/theme-customization", payload)
+		"primary_color_light":         light.Primary,
+		"secondary_color_light":       light.Secondary,
+		"background_color_light":      light.Background,
+		"card_background_color_light": light.CardBackground,
+		"text_color_light":            light.Text,
+		"text_secondary_color_light":  light.TextSecondary,
+		"accent_color_light":          light.Accent,
+		"success_color_light":         light.Success,
+		"error_color_light":           light.Error,
+		"warning_color_light":         light.Warning,
+		"info_color_light":            light.Info,
+
+		// Dark mode (cores *_dark do seed ou derivadas das light, ver DarkPalette)
+		"primary_color_dark":         dark.Primary,
+		"secondary_color_dark":       dark.Secondary,
+		"background_color_dark":      dark.Background,
+		"card_background_color_dark": dark.CardBackground,
+		"text_color_dark":            dark.Text,
+		"text_secondary_color_dark":  dark.TextSecondary,
+		"accent_color_dark":          dark.Accent,
+		"success_color_dark":         dark.Success,
+		"error_color_dark":           dark.Error,
+		"warning_color_dark":         dark.Warning,
+		"info_color_dark":            dark.Info,
+
+		"disabled_opacity": theme.DisabledOpacity,
+		"shadow_intensity": theme.ShadowIntensity,
+		"is_active":        theme.IsActive,
+	}
+
+	resp, status, err := c.doRequest(ctx, "POST", "/theme-customization", payload)
 	if err != nil {
 		return err
 	}
 
 	if status == 409 {
 		// Theme já existe, tentar atualizar
-		_, status, err = c.doRequest("PUT", "/theme-customization", payload)
+		resp, status, err = c.doRequest(ctx, "PUT", "/theme-customization", payload)
 		if err != nil {
 			return err
 		}
 	}
 
 	if status != 200 && status != 201 {
-		return fmt.Errorf("status %d", status)
+		return newAPIError(status, resp)
 	}
 
 	return nil
 }
 
-// GetNotificationTemplateByName busca template por nome
-func (c *APIClientV2) GetNotificationTemplateByName(name string) (uuid.UUID, error) {
-	resp, status, err := c.doRequest("GET", "/notification-template", nil)
+// UpdateDisplaySettings atualiza as configurações de exibição do projeto, enviando apenas os campos definidos
+func (c *APIClientV2) UpdateDisplaySettings(ctx context.Context, settings *DisplaySettingsData) error {
+	payload := map[string]interface{}{}
+
+	if settings.ShowPrices != nil {
+		payload["show_prices"] = *settings.ShowPrices
+	}
+	if settings.ShowDescriptions != nil {
+		payload["show_descriptions"] = *settings.ShowDescriptions
+	}
+	if settings.ShowImages != nil {
+		payload["show_images"] = *settings.ShowImages
+	}
+	if settings.ItemPerPage != nil {
+		payload["item_per_page"] = *settings.ItemPerPage
+	}
+
+	resp, status, err := c.doRequest(ctx, "PUT", "/project/settings/display", payload)
 	if err != nil {
-		return uuid.Nil, err
+		return err
+	}
+
+	if status != 200 && status != 201 {
+		return newAPIError(status, resp)
+	}
+
+	return nil
+}
+
+// ResetDisplaySettings restaura as configurações de exibição padrão do projeto
+func (c *APIClientV2) ResetDisplaySettings(ctx context.Context) error {
+	resp, status, err := c.doRequest(ctx, "POST", "/project/settings/display/reset", nil)
+	if err != nil {
+		return err
 	}
 
 	if status != 200 {
-		return uuid.Nil, fmt.Errorf("status %d", status)
+		return newAPIError(status, resp)
 	}
 
-	if templates, ok := resp["data"].([]interface{}); ok {
-		for _, t := range templates {
-			if template, ok := t.(map[string]interface{}); ok {
-				if tName, ok := template["name"].(string); ok && tName == name {
-					if id, ok := template["id"].(string); ok {
-						return uuid.Parse(id)
-					}
-				}
-			}
+	return nil
+}
+
+// SetMenuManualOverride fixa o menu como override manual da seleção automática
+func (c *APIClientV2) SetMenuManualOverride(ctx context.Context, menuID string) error {
+	resp, status, err := c.doRequest(ctx, "PUT", fmt.Sprintf("/menu/%s/manual-override", menuID), map[string]interface{}{})
+	if err != nil {
+		return err
+	}
+
+	if status != 200 && status != 201 {
+		return newAPIError(status, resp)
+	}
+
+	return nil
+}
+
+// ClearMenuManualOverride remove o override manual, voltando à seleção automática.
+// 404 significa que não havia override ativo.
+func (c *APIClientV2) ClearMenuManualOverride(ctx context.Context) error {
+	resp, status, err := c.doRequest(ctx, "DELETE", "/menu/manual-override", nil)
+	if err != nil {
+		return err
+	}
+
+	if status != 200 && status != 204 && status != 404 {
+		return newAPIError(status, resp)
+	}
+
+	return nil
+}
+
+// GetProjectTheme busca o tema ativo do projeto (GET /project/settings/theme) e retorna as paletas light e dark
+func (c *APIClientV2) GetProjectTheme(ctx context.Context) (light, dark ThemePalette, err error) {
+	resp, status, err := c.doRequest(ctx, "GET", "/project/settings/theme", nil)
+	if err != nil {
+		return ThemePalette{}, ThemePalette{}, err
+	}
+
+	if status != 200 {
+		return ThemePalette{}, ThemePalette{}, newAPIError(status, resp)
+	}
+
+	fields := resp
+	if data, ok := resp["data"].(map[string]interface{}); ok {
+		fields = data
+	}
+
+	return themePaletteFromPayload(fields, "_light"), themePaletteFromPayload(fields, "_dark"), nil
+}
+
+// GetNotificationTemplateByName busca template por nome
+func (c *APIClientV2) GetNotificationTemplateByName(ctx context.Context, name string) (uuid.UUID, error) {
+	return c.findByField(ctx, "/notification-template", "name", name)
+}
+
+// UploadImage envia imagem pelo endpoint multipart /upload/{entity}/image e retorna a image_url.
+// Como qualquer POST, só é repetido quando a tentativa comprovadamente não chegou ao backend.
+func (c *APIClientV2) UploadImage(ctx context.Context, entity, fileName string, data []byte) (string, error) {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="image"; filename="%s"`, fileName))
+	header.Set("Content-Type", http.DetectContentType(data))
+
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return "", fmt.Errorf("erro ao criar form part: %w", err)
+	}
+	if _, err := part.Write(data); err != nil {
+		return "", fmt.Errorf("erro ao escrever imagem: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("erro ao finalizar multipart: %w", err)
+	}
+
+	path := fmt.Sprintf("/upload/%s/image", entity)
+	bodyBytes := body.Bytes()
+	contentType := writer.FormDataContentType()
+
+	resp, status, err := c.sendWithRetry(ctx, "POST", path, false, func() (map[string]interface{}, int, http.Header, error) {
+		return c.doRequestOnce(ctx, "POST", path, bodyBytes, contentType)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if status != 200 && status != 201 {
+		return "", newAPIError(status, resp)
+	}
+
+	if data, ok := resp["data"].(map[string]interface{}); ok {
+		if url, ok := data["image_url"].(string); ok && url != "" {
+			return url, nil
 		}
 	}
+	if url, ok := resp["url"].(string); ok && url != "" {
+		return url, nil
+	}
+
+	return "", fmt.Errorf("image_url não encontrada na resposta")
+}
+
+// SetEntityImage associa a image_url a uma entidade (product, category).
+// Produto tem endpoint próprio (PUT /product/{id}/image); categoria não, então a image_url é
+// mesclada à entidade atual, já que o PUT da entidade substitui todos os campos.
+func (c *APIClientV2) SetEntityImage(ctx context.Context, entity, id, imageURL string) error {
+	if entity != "product" {
+		path := "/" + entity
+		c.cache.invalidate(c.cacheKey(path))
+		current, err := c.findByID(ctx, path, id)
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			return fmt.Errorf("%s %s não encontrado", entity, id)
+		}
+		return c.UpdateEntity(ctx, path, id, mergeFields(current, map[string]interface{}{"image_url": imageURL}))
+	}
+
+	payload := map[string]interface{}{
+		"image_url": imageURL,
+	}
+
+	resp, status, err := c.doRequest(ctx, "PUT", fmt.Sprintf("/product/%s/image", id), payload)
+	if err != nil {
+		return err
+	}
 
-	return uuid.Nil, fmt.Errorf("não encontrado")
+	if status != 200 && status != 201 {
+		return newAPIError(status, resp)
+	}
+
+	return nil
 }
 
 // extractIDFromResponse extrai ID da resposta JSON
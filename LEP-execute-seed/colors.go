@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// parseHexColor interpreta cores no formato #RGB ou #RRGGBB
+func parseHexColor(hex string) (color.RGBA, error) {
+	value := strings.TrimPrefix(strings.TrimSpace(hex), "#")
+
+	if len(value) == 3 {
+		value = string([]byte{value[0], value[0], value[1], value[1], value[2], value[2]})
+	}
+	if len(value) != 6 || !strings.HasPrefix(strings.TrimSpace(hex), "#") {
+		return color.RGBA{}, fmt.Errorf("cor inválida %q (esperado #RGB ou #RRGGBB)", hex)
+	}
+
+	n, err := strconv.ParseUint(value, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("cor inválida %q (esperado #RGB ou #RRGGBB)", hex)
+	}
+
+	return color.RGBA{R: uint8(n >> 16), G: uint8(n >> 8), B: uint8(n), A: 255}, nil
+}
+
+// relativeLuminance calcula a luminância relativa segundo WCAG 2.1
+func relativeLuminance(c color.RGBA) float64 {
+	channel := func(v uint8) float64 {
+		s := float64(v) / 255
+		if s <= 0.03928 {
+			return s / 12.92
+		}
+		return math.Pow((s+0.055)/1.055, 2.4)
+	}
+
+	return 0.2126*channel(c.R) + 0.7152*channel(c.G) + 0.0722*channel(c.B)
+}
+
+// formatHexColor formata a cor como #rrggbb
+func formatHexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// rgbToHSL converte para matiz (0-360), saturação e luminosidade (0-1)
+func rgbToHSL(c color.RGBA) (h, s, l float64) {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+	max := math.Max(r, math.Max(g, b))
+	min := math.Min(r, math.Min(g, b))
+	l = (max + min) / 2
+
+	if max == min {
+		return 0, 0, l
+	}
+
+	d := max - min
+	if l > 0.5 {
+		s = d / (2 - max - min)
+	} else {
+		s = d / (max + min)
+	}
+
+	switch max {
+	case r:
+		h = (g - b) / d
+		if g < b {
+			h += 6
+		}
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+
+	return h * 60, s, l
+}
+
+// hslToRGB converte matiz (0-360), saturação e luminosidade (0-1) para RGB
+func hslToRGB(h, s, l float64) color.RGBA {
+	if s == 0 {
+		v := uint8(math.Round(l * 255))
+		return color.RGBA{R: v, G: v, B: v, A: 255}
+	}
+
+	var q float64
+	if l < 0.5 {
+		q = l * (1 + s)
+	} else {
+		q = l + s - l*s
+	}
+	p := 2*l - q
+
+	hueToChannel := func(t float64) uint8 {
+		if t < 0 {
+			t += 1
+		}
+		if t > 1 {
+			t -= 1
+		}
+		var v float64
+		switch {
+		case t < 1.0/6:
+			v = p + (q-p)*6*t
+		case t < 1.0/2:
+			v = q
+		case t < 2.0/3:
+			v = p + (q-p)*(2.0/3-t)*6
+		default:
+			v = p
+		}
+		return uint8(math.Round(v * 255))
+	}
+
+	hn := h / 360
+	return color.RGBA{R: hueToChannel(hn + 1.0/3), G: hueToChannel(hn), B: hueToChannel(hn - 1.0/3), A: 255}
+}
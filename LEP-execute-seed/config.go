@@ -1,19 +1,23 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"net/url"
 	"os"
 	"strings"
 
 	"gopkg.in/yaml.v2"
+	"lep-shared/logging"
 )
 
 // Config representa a configuração do seeder
 type Config struct {
 	Server struct {
-		URL     string `yaml:"url"`
-		Timeout int    `yaml:"timeout"`
+		URL         string `yaml:"url"`
+		Timeout     int    `yaml:"timeout"`      // segundos por requisição
+		StepTimeout int    `yaml:"step_timeout"` // segundos por passo do seed; 0 desativa
 	} `yaml:"server"`
 
 	Auth struct {
@@ -24,28 +28,76 @@ type Config struct {
 	} `yaml:"auth"`
 
 	Seed struct {
-		File        string `yaml:"file"`
-		StopOnError bool   `yaml:"stop_on_error"`
-		Parallel    bool   `yaml:"parallel"`
+		File              string `yaml:"file"`
+		StopOnError       bool   `yaml:"stop_on_error"`
+		Parallel          bool   `yaml:"parallel"`
+		Cache             bool   `yaml:"cache"`
+		PageSize          int    `yaml:"page_size"`
+		BatchSize         int    `yaml:"batch_size"`
+		ImageLedger       string `yaml:"image_ledger"`
+		IDLedger          string `yaml:"id_ledger"`
+		PlaceholderImages bool   `yaml:"placeholder_images"`
+		StrictContrast    bool   `yaml:"strict_contrast"`
+		PruneLinks        bool   `yaml:"prune_links"`
 	} `yaml:"seed"`
 
 	Logging struct {
-		Level        string `yaml:"level"`
+		Level        string `yaml:"level"` // debug, info, warn ou error
 		ShowPayloads bool   `yaml:"show_payloads"`
+		Format       string `yaml:"format"` // text ou json
+		File         string `yaml:"file"`   // também grava o log neste arquivo
 	} `yaml:"logging"`
+
+	Retry struct {
+		MaxAttempts      int `yaml:"max_attempts"`
+		InitialBackoffMs int `yaml:"initial_backoff_ms"`
+		MaxBackoffMs     int `yaml:"max_backoff_ms"`
+	} `yaml:"retry"`
+
+	Migrations struct {
+		Dir    string `yaml:"dir"`
+		Ledger string `yaml:"ledger"`
+	} `yaml:"migrations"`
+
+	Command []string `yaml:"-"` // subcomando, ex.: migrate status
+
+	Report string `yaml:"report"` // caminho do relatório JSON (-report); vazio desativa
+
+	Lint struct {
+		Layout map[string]string `yaml:"layout"` // regra -> error, warning ou off
+		Strict bool              `yaml:"strict"` // avisos de layout abortam o seed
+	} `yaml:"lint"`
+
+	Env              string                        `yaml:"-"` // -env: perfil de environments
+	AllowProdDeletes bool                          `yaml:"-"` // -allow-prod-deletes
+	Environments     map[string]EnvironmentProfile `yaml:"environments"`
+
+	sources configSources // origem de cada valor, para "config print"
+
+	Sync struct {
+		Enabled bool     `yaml:"enabled"`
+		Prune   []string `yaml:"prune"` // tipos de entidade que o sync pode apagar
+		Confirm bool     `yaml:"-"`     // -yes: só pela linha de comando
+	} `yaml:"sync"`
 }
 
-// LoadConfig carrega configuração de arquivo YAML + flags de linha de comando
+// defaultSeedFile é o seed.file padrão; com ele são executados seed-fattoria.json e seed-data.json
+const defaultSeedFile = "seed-fattoria.json"
+
+// LoadConfig carrega a configuração em camadas: padrões, arquivo YAML (-config),
+// variáveis LEP_SEED_* e por fim as flags de linha de comando
 func LoadConfig() (*Config, error) {
 	config := &Config{
 		Server: struct {
-			URL     string `yaml:"url"`
-			Timeout int    `yaml:"timeout"`
+			URL         string `yaml:"url"`
+			Timeout     int    `yaml:"timeout"`      // segundos por requisição
+			StepTimeout int    `yaml:"step_timeout"` // segundos por passo do seed; 0 desativa
 		}{
 			// Defaults
 			URL: "http://localhost:8080",
 			//URL:     "https://lep-system-516622888070.us-central1.run.app",
-			Timeout: 30,
+			Timeout:     30,
+			StepTimeout: 300,
 		},
 		Auth: struct {
 			OrganizationName string `yaml:"organization_name"`
@@ -58,71 +110,210 @@ func LoadConfig() (*Config, error) {
 			AutoEmail:        true,
 		},
 		Seed: struct {
-			File        string `yaml:"file"`
-			StopOnError bool   `yaml:"stop_on_error"`
-			Parallel    bool   `yaml:"parallel"`
+			File              string `yaml:"file"`
+			StopOnError       bool   `yaml:"stop_on_error"`
+			Parallel          bool   `yaml:"parallel"`
+			Cache             bool   `yaml:"cache"`
+			PageSize          int    `yaml:"page_size"`
+			BatchSize         int    `yaml:"batch_size"`
+			ImageLedger       string `yaml:"image_ledger"`
+			IDLedger          string `yaml:"id_ledger"`
+			PlaceholderImages bool   `yaml:"placeholder_images"`
+			StrictContrast    bool   `yaml:"strict_contrast"`
+			PruneLinks        bool   `yaml:"prune_links"`
 		}{
-			File:        "seed-fattoria.json",
-			StopOnError: false,
-			Parallel:    false,
+			File:              defaultSeedFile,
+			StopOnError:       false,
+			Parallel:          false,
+			Cache:             true,
+			PageSize:          100,
+			BatchSize:         50,
+			ImageLedger:       ".seed-images.json",
+			IDLedger:          ".seed-ids.json",
+			PlaceholderImages: true,
 		},
 		Logging: struct {
-			Level        string `yaml:"level"`
+			Level        string `yaml:"level"` // debug, info, warn ou error
 			ShowPayloads bool   `yaml:"show_payloads"`
+			Format       string `yaml:"format"` // text ou json
+			File         string `yaml:"file"`   // também grava o log neste arquivo
 		}{
 			Level:        "info",
 			ShowPayloads: false,
+			Format:       "text",
+		},
+		Retry: struct {
+			MaxAttempts      int `yaml:"max_attempts"`
+			InitialBackoffMs int `yaml:"initial_backoff_ms"`
+			MaxBackoffMs     int `yaml:"max_backoff_ms"`
+		}{
+			MaxAttempts:      4,
+			InitialBackoffMs: 500,
+			MaxBackoffMs:     8000,
 		},
 	}
 
-	// 1. Tentar carregar config.yaml se existir
-	if _, err := os.Stat("config.yaml"); err == nil {
-		fmt.Println("[ℹ] Carregando config.yaml...")
-		data, err := os.ReadFile("config.yaml")
-		if err != nil {
-			return nil, fmt.Errorf("erro ao ler config.yaml: %w", err)
-		}
+	config.Migrations.Dir = "migrations"
+	config.Migrations.Ledger = ".seed-migrations.json"
+
+	sources := newConfigSources(config)
+
+	// 1. Flags de linha de comando: lidas primeiro (para achar -config), aplicadas por último
+	configPath := flag.String("config", envOrDefault("LEP_SEED_CONFIG", "config.yaml"), "Arquivo de configuração YAML (ou LEP_SEED_CONFIG)")
+	flags := &configFlags{}
+	flags.String("url", "server.url", &config.Server.URL, "URL base da API LEP")
+	flags.String("file", "seed.file", &config.Seed.File, "Arquivo JSON com dados de seed")
+	flags.String("log-level", "logging.level", &config.Logging.Level, "Nível de log: debug, info, warn ou error")
+	flags.String("log-format", "logging.format", &config.Logging.Format, "Formato do log: text ou json")
+	flags.String("log-file", "logging.file", &config.Logging.File, "Gravar o log também neste arquivo")
+	flags.Switch("verbose", func() {
+		config.Logging.Level = "debug"
+		config.Logging.ShowPayloads = true
+	}, "Ativar modo verbose", "logging.level", "logging.show_payloads")
+	flags.String("org", "auth.organization_name", &config.Auth.OrganizationName, "Nome da organização")
+	flags.Int("timeout", "server.timeout", &config.Server.Timeout, "Timeout de cada requisição em segundos")
+	flags.Int("step-timeout", "server.step_timeout", &config.Server.StepTimeout, "Timeout de cada passo do seed em segundos (0 desativa)")
+	flags.Int("batch-size", "seed.batch_size", &config.Seed.BatchSize, "Produtos por lote no endpoint /product/bulk (0 ou 1 desativa o bulk)")
+	flags.Switch("no-placeholders", func() { config.Seed.PlaceholderImages = false }, "Não gerar imagens placeholder para produtos e categorias sem foto", "seed.placeholder_images")
+	flags.Switch("no-cache", func() { config.Seed.Cache = false }, "Desativar cache de coleções (lista o backend a cada busca, para debug)", "seed.cache")
+	flags.Switch("strict-contrast", func() { config.Seed.StrictContrast = true }, "Abortar quando o tema não atinge o contraste WCAG AA", "seed.strict_contrast")
+	flags.Switch("prune-links", func() { config.Seed.PruneLinks = true }, "Remover vínculos (subcategoria-categoria, usuário-organização/projeto) que não estão no seed", "seed.prune_links")
+	flags.Bool("sync", "sync.enabled", &config.Sync.Enabled, "Sincronizar: atualizar entidades divergentes e apagar as que não estão no seed (tipos em sync.prune)")
+	flags.Bool("yes", "", &config.Sync.Confirm, "Confirmar as deleções do -sync (sem ele o plano é apenas exibido)")
+	flags.Switch("strict-lint", func() { config.Lint.Strict = true }, "Abortar o seed também nos avisos do lint de ambientes, mesas e reservas", "lint.strict")
+	flags.String("report", "report", &config.Report, "Gravar relatório JSON da execução neste arquivo")
+	flags.String("migrations", "migrations.dir", &config.Migrations.Dir, "Diretório de migrations (comando migrate)")
+	flags.String("env", "env", &config.Env, "Ambiente alvo definido em environments (local, staging, prod); obrigatório para URLs remotas")
+	flags.Bool("allow-prod-deletes", "allow_prod_deletes", &config.AllowProdDeletes, "Permitir -sync -yes, -prune-links e migrations com delete/unlink em ambiente protegido")
+	flags.Int("retries", "retry.max_attempts", &config.Retry.MaxAttempts, "Número máximo de tentativas por requisição (1 desativa retry)")
+
+	// Subcomandos (ex.: "migrate status") vêm antes ou depois das flags
+	command, args := splitCommand(os.Args[1:])
+	flag.CommandLine.Parse(args)
+	config.Command = append(command, flag.Args()...)
+
+	// 2. Arquivo de configuração; o padrão (config.yaml) é opcional, um caminho explícito não
+	_, explicit := os.LookupEnv("LEP_SEED_CONFIG")
+	flag.Visit(func(f *flag.Flag) { explicit = explicit || f.Name == "config" })
+	if err := config.loadFile(*configPath, explicit, sources); err != nil {
+		return nil, err
+	}
+
+	// 3. Variáveis de ambiente LEP_SEED_*
+	if err := config.applyEnvOverrides(sources); err != nil {
+		return nil, err
+	}
+
+	// 4. Flags passadas explicitamente
+	flags.apply(sources)
+
+	if err := config.applyEnvironment(sources); err != nil {
+		return nil, err
+	}
+	config.sources = sources
+
+	// Erros de validação ainda retornam a config, para que "config print" mostre a origem do valor inválido
+	return config, config.Validate()
+}
+
+// loadFile lê o arquivo YAML e marca as chaves presentes como origem dos valores
+func (c *Config) loadFile(path string, required bool, sources configSources) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) && !required {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao ler %s: %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return fmt.Errorf("erro ao parsear %s: %w", path, err)
+	}
+
+	var keys map[interface{}]interface{}
+	if err := yaml.Unmarshal(data, &keys); err != nil {
+		return fmt.Errorf("erro ao parsear %s: %w", path, err)
+	}
+	sources.markFile(path, "", keys)
+	return nil
+}
 
-		if err := yaml.Unmarshal(data, config); err != nil {
-			return nil, fmt.Errorf("erro ao parsear config.yaml: %w", err)
+// Validate confere a configuração efetiva e retorna todos os problemas encontrados
+func (c *Config) Validate() error {
+	var errs []error
+
+	if err := validateURL("server.url", c.Server.URL); err != nil {
+		errs = append(errs, err)
+	}
+	if c.Server.Timeout <= 0 {
+		errs = append(errs, fmt.Errorf("server.timeout deve ser positivo (recebido %d)", c.Server.Timeout))
+	}
+	if c.Server.StepTimeout < 0 {
+		errs = append(errs, fmt.Errorf("server.step_timeout não pode ser negativo (0 desativa)"))
+	}
+	if c.Retry.MaxAttempts <= 0 {
+		errs = append(errs, fmt.Errorf("retry.max_attempts deve ser positivo (recebido %d; 1 desativa retry)", c.Retry.MaxAttempts))
+	}
+	if c.Seed.BatchSize < 0 {
+		errs = append(errs, fmt.Errorf("seed.batch_size não pode ser negativo (0 ou 1 desativa o bulk)"))
+	}
+	for _, name := range c.Sync.Prune {
+		if !isSyncEntity(name) {
+			errs = append(errs, fmt.Errorf("sync.prune: tipo desconhecido %q (use %s)", name, strings.Join(syncEntityNames(), ", ")))
 		}
-		fmt.Println("[✓] config.yaml carregado com sucesso")
+	}
+	for _, name := range c.environmentNames() {
+		if err := validateURL("environments."+name+".url", c.Environments[name].URL); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if _, err := c.LogOptions(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := ValidateLayoutConfig(c.Lint.Layout); err != nil {
+		errs = append(errs, err)
 	}
 
-	// 2. Sobrescrever com flags de linha de comando
-	url := flag.String("url", config.Server.URL, "URL base da API LEP")
-	file := flag.String("file", config.Seed.File, "Arquivo JSON com dados de seed")
-	verbose := flag.Bool("verbose", false, "Ativar modo verbose")
-	org := flag.String("org", config.Auth.OrganizationName, "Nome da organização")
-	timeout := flag.Int("timeout", config.Server.Timeout, "Timeout em segundos")
+	// O arquivo padrão é opcional (determineSeedFiles roda os que existirem); um -file explícito precisa existir
+	if c.Seed.File != defaultSeedFile {
+		if _, err := os.Stat(c.Seed.File); err != nil {
+			errs = append(errs, fmt.Errorf("seed.file %s não encontrado", c.Seed.File))
+		}
+	}
 
-	flag.Parse()
+	return errors.Join(errs...)
+}
 
-	// Aplicar flags se foram definidas
-	config.Server.URL = *url
-	config.Seed.File = *file
-	config.Auth.OrganizationName = *org
-	config.Server.Timeout = *timeout
+// validateURL exige esquema http ou https e host
+func validateURL(field, raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%s inválida %q (esperado http://host ou https://host)", field, raw)
+	}
+	return nil
+}
 
-	if *verbose {
-		config.Logging.Level = "debug"
-		config.Logging.ShowPayloads = true
+// LogOptions converte a seção logging nas opções do Logger
+func (c *Config) LogOptions() (logging.Options, error) {
+	level, err := logging.ParseLevel(c.Logging.Level)
+	if err != nil {
+		return logging.Options{}, err
+	}
+	if c.Logging.Format != "text" && c.Logging.Format != "json" {
+		return logging.Options{}, fmt.Errorf("logging.format inválido %q (use text ou json)", c.Logging.Format)
 	}
 
-	return config, nil
+	return logging.Options{Level: level, JSON: c.Logging.Format == "json", File: c.Logging.File}, nil
 }
 
-// Print exibe a configuração carregada
-func (c *Config) Print() {
-	fmt.Println("\n========== CONFIGURAÇÃO ==========")
-	fmt.Printf("[ℹ] URL Backend: %s\n", c.Server.URL)
-	fmt.Printf("[ℹ] Arquivo: %s\n", c.Seed.File)
-	fmt.Printf("[ℹ] Organização: %s\n", c.Auth.OrganizationName)
-	fmt.Printf("[ℹ] Log Level: %s\n", c.Logging.Level)
-	if c.Logging.ShowPayloads {
-		fmt.Printf("[ℹ] Mostrando payloads: SIM\n")
-	}
-	fmt.Println("==================================\n")
+// splitCommand separa as palavras iniciais que não são flags (o subcomando) do restante dos argumentos
+func splitCommand(args []string) (command, rest []string) {
+	for i, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			return args[:i], args[i:]
+		}
+	}
+	return args, nil
 }
 
 // GetEmailSlug retorna o slug da organização para criar email
@@ -0,0 +1,342 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// envPrefix é o prefixo das variáveis que sobrescrevem a config (LEP_SEED_SERVER_URL -> server.url)
+const envPrefix = "LEP_SEED_"
+
+// configSources registra de onde veio cada valor efetivo, pelo caminho YAML (ex.: server.url)
+type configSources map[string]string
+
+// newConfigSources marca todos os campos da config como valor padrão
+func newConfigSources(c *Config) configSources {
+	sources := make(configSources)
+	for _, f := range append(c.configFields(), c.commandLineFields()...) {
+		sources[f.path] = "padrão"
+	}
+	return sources
+}
+
+// markFile marca as chaves presentes no YAML (inclusive perfis de environments)
+func (s configSources) markFile(file, prefix string, keys map[interface{}]interface{}) {
+	for k, v := range keys {
+		path := joinConfigPath(prefix, fmt.Sprint(k))
+		s[path] = file
+		if nested, ok := v.(map[interface{}]interface{}); ok {
+			s.markFile(file, path, nested)
+		}
+	}
+}
+
+func joinConfigPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// envName converte o caminho YAML no nome da variável de ambiente
+func envName(path string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(path, ".", "_"))
+}
+
+func envOrDefault(name, def string) string {
+	if v, ok := os.LookupEnv(name); ok {
+		return v
+	}
+	return def
+}
+
+// configField é um campo da config lido do YAML
+type configField struct {
+	path  string
+	value reflect.Value
+}
+
+// configFields lista os campos com tag yaml, na ordem da struct.
+// Mapas de perfis (environments) ficam de fora: cada perfil é tratado à parte.
+func (c *Config) configFields() []configField {
+	var fields []configField
+
+	var walk func(v reflect.Value, prefix string)
+	walk = func(v reflect.Value, prefix string) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			tag := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+			if tag == "" || tag == "-" {
+				continue
+			}
+
+			path := joinConfigPath(prefix, tag)
+			field := v.Field(i)
+			switch {
+			case field.Kind() == reflect.Struct:
+				walk(field, path)
+			case field.Kind() == reflect.Map && field.Type().Elem().Kind() == reflect.Struct:
+			default:
+				fields = append(fields, configField{path: path, value: field})
+			}
+		}
+	}
+	walk(reflect.ValueOf(c).Elem(), "")
+
+	return fields
+}
+
+// commandLineFields são os campos que só vêm da linha de comando (yaml:"-", sem variável de ambiente):
+// escolher o ambiente e liberar deleções em produção é sempre explícito. Entram em "config print".
+func (c *Config) commandLineFields() []configField {
+	return []configField{
+		{path: "env", value: reflect.ValueOf(&c.Env).Elem()},
+		{path: "allow_prod_deletes", value: reflect.ValueOf(&c.AllowProdDeletes).Elem()},
+	}
+}
+
+// applyEnvOverrides aplica as variáveis LEP_SEED_* sobre os valores do arquivo.
+// Listas são separadas por vírgula (LEP_SEED_SYNC_PRUNE=menu,tag); mapas usam chave=valor
+// (LEP_SEED_LINT_LAYOUT=party_size=off,capacity_sum=error); perfis usam
+// LEP_SEED_ENVIRONMENTS_<NOME>_URL e LEP_SEED_ENVIRONMENTS_<NOME>_PROTECTED.
+func (c *Config) applyEnvOverrides(sources configSources) error {
+	for _, f := range c.configFields() {
+		name := envName(f.path)
+		raw, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if err := setFromString(f.value, raw); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		sources[f.path] = "env " + name
+	}
+
+	return c.applyEnvironmentOverrides(sources)
+}
+
+// applyEnvironmentOverrides cria ou altera perfis de environments a partir de LEP_SEED_ENVIRONMENTS_*
+func (c *Config) applyEnvironmentOverrides(sources configSources) error {
+	prefix := envName("environments") + "_"
+
+	var names []string
+	for _, kv := range os.Environ() {
+		if strings.HasPrefix(kv, prefix) {
+			names = append(names, strings.SplitN(kv, "=", 2)[0])
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		rest := strings.TrimPrefix(name, prefix)
+		raw := os.Getenv(name)
+
+		var profileName, field string
+		switch {
+		case strings.HasSuffix(rest, "_URL"):
+			profileName, field = strings.TrimSuffix(rest, "_URL"), "url"
+		case strings.HasSuffix(rest, "_PROTECTED"):
+			profileName, field = strings.TrimSuffix(rest, "_PROTECTED"), "protected"
+		default:
+			return fmt.Errorf("%s: campo desconhecido (use _URL ou _PROTECTED)", name)
+		}
+		profileName = strings.ToLower(profileName)
+
+		if c.Environments == nil {
+			c.Environments = make(map[string]EnvironmentProfile)
+		}
+		profile := c.Environments[profileName]
+		if field == "url" {
+			profile.URL = raw
+		} else {
+			protected, err := strconv.ParseBool(raw)
+			if err != nil {
+				return fmt.Errorf("%s: esperado true ou false, recebido %q", name, raw)
+			}
+			profile.Protected = protected
+		}
+		c.Environments[profileName] = profile
+		sources["environments."+profileName+"."+field] = "env " + name
+	}
+	return nil
+}
+
+// setFromString converte o texto da variável para o tipo do campo
+func setFromString(v reflect.Value, raw string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Int:
+		n, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil {
+			return fmt.Errorf("esperado número inteiro, recebido %q", raw)
+		}
+		v.SetInt(int64(n))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return fmt.Errorf("esperado true ou false, recebido %q", raw)
+		}
+		v.SetBool(b)
+	case reflect.Slice:
+		list := reflect.MakeSlice(v.Type(), 0, 0)
+		for _, item := range splitList(raw) {
+			list = reflect.Append(list, reflect.ValueOf(item))
+		}
+		v.Set(list)
+	case reflect.Map:
+		m := reflect.MakeMap(v.Type())
+		for _, item := range splitList(raw) {
+			key, value, ok := strings.Cut(item, "=")
+			if !ok {
+				return fmt.Errorf("esperado chave=valor, recebido %q", item)
+			}
+			m.SetMapIndex(reflect.ValueOf(strings.TrimSpace(key)), reflect.ValueOf(strings.TrimSpace(value)))
+		}
+		v.Set(m)
+	default:
+		return fmt.Errorf("tipo %s não suportado", v.Type())
+	}
+	return nil
+}
+
+// splitList separa itens por vírgula, ignorando vazios
+func splitList(raw string) []string {
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// configFlags registra flags ligadas a campos da config; só as passadas na linha de comando são aplicadas
+type configFlags struct {
+	bindings []flagBinding
+}
+
+type flagBinding struct {
+	name  string
+	paths []string // campos que a flag altera, para "config print"
+	apply func()
+}
+
+func (f *configFlags) bind(name string, apply func(), paths ...string) {
+	var nonEmpty []string
+	for _, p := range paths {
+		if p != "" {
+			nonEmpty = append(nonEmpty, p)
+		}
+	}
+	f.bindings = append(f.bindings, flagBinding{name: name, paths: nonEmpty, apply: apply})
+}
+
+func (f *configFlags) String(name, path string, target *string, usage string) {
+	v := flag.String(name, *target, usage)
+	f.bind(name, func() { *target = *v }, path)
+}
+
+func (f *configFlags) Int(name, path string, target *int, usage string) {
+	v := flag.Int(name, *target, usage)
+	f.bind(name, func() { *target = *v }, path)
+}
+
+func (f *configFlags) Bool(name, path string, target *bool, usage string) {
+	v := flag.Bool(name, *target, usage)
+	f.bind(name, func() { *target = *v }, path)
+}
+
+// Switch registra uma flag booleana que, quando ligada, executa apply (ex.: -no-cache)
+func (f *configFlags) Switch(name string, apply func(), usage string, paths ...string) {
+	v := flag.Bool(name, false, usage)
+	f.bind(name, func() {
+		if *v {
+			apply()
+		}
+	}, paths...)
+}
+
+// apply aplica, na ordem de registro, as flags passadas na linha de comando
+func (f *configFlags) apply(sources configSources) {
+	set := make(map[string]bool)
+	flag.Visit(func(fl *flag.Flag) { set[fl.Name] = true })
+
+	for _, b := range f.bindings {
+		if !set[b.name] {
+			continue
+		}
+		b.apply()
+		for _, path := range b.paths {
+			sources[path] = "flag -" + b.name
+		}
+	}
+}
+
+// secretFields não têm o valor exibido por "config print"
+var secretFields = map[string]bool{
+	"auth.fallback_password": true,
+}
+
+// Print exibe cada valor efetivo da configuração e sua origem (comando "config print")
+func (c *Config) Print(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CAMPO\tVALOR\tORIGEM")
+
+	for _, f := range c.configFields() {
+		value := formatConfigValue(f.value)
+		if secretFields[f.path] && value != `""` {
+			value = "********"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", f.path, value, c.sources[f.path])
+	}
+
+	for _, name := range c.environmentNames() {
+		profile := c.Environments[name]
+		for _, field := range []struct {
+			key   string
+			value string
+		}{{"url", strconv.Quote(profile.URL)}, {"protected", strconv.FormatBool(profile.Protected)}} {
+			path := "environments." + name + "." + field.key
+			source := c.sources[path]
+			if source == "" {
+				source = "padrão"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", path, field.value, source)
+		}
+	}
+
+	for _, f := range c.commandLineFields() {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", f.path, formatConfigValue(f.value), c.sources[f.path])
+	}
+	tw.Flush()
+}
+
+// formatConfigValue formata o valor para "config print"; mapas saem com chaves ordenadas
+func formatConfigValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return strconv.Quote(v.String())
+	case reflect.Slice:
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = fmt.Sprint(v.Index(i).Interface())
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case reflect.Map:
+		var items []string
+		for _, k := range v.MapKeys() {
+			items = append(items, fmt.Sprintf("%v=%v", k.Interface(), v.MapIndex(k).Interface()))
+		}
+		sort.Strings(items)
+		return "{" + strings.Join(items, ", ") + "}"
+	}
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+)
+
+// Razões mínimas de contraste do WCAG 2.1 nível AA
+const (
+	wcagAAText    = 4.5 // texto normal
+	wcagAANonText = 3.0 // componentes de interface e texto grande
+)
+
+// contrastPair é um par frente/fundo do tema auditado
+type contrastPair struct {
+	name       string
+	foreground func(ThemePalette) string
+	background func(ThemePalette) string
+	required   float64
+}
+
+// themeContrastPairs são os pares que o frontend efetivamente exibe juntos
+var themeContrastPairs = []contrastPair{
+	{"texto / fundo", func(p ThemePalette) string { return p.Text }, func(p ThemePalette) string { return p.Background }, wcagAAText},
+	{"texto / card", func(p ThemePalette) string { return p.Text }, func(p ThemePalette) string { return p.CardBackground }, wcagAAText},
+	{"texto secundário / fundo", func(p ThemePalette) string { return p.TextSecondary }, func(p ThemePalette) string { return p.Background }, wcagAAText},
+	{"texto secundário / card", func(p ThemePalette) string { return p.TextSecondary }, func(p ThemePalette) string { return p.CardBackground }, wcagAAText},
+	{"destaque / fundo", func(p ThemePalette) string { return p.Accent }, func(p ThemePalette) string { return p.Background }, wcagAANonText},
+	{"destaque / card", func(p ThemePalette) string { return p.Accent }, func(p ThemePalette) string { return p.CardBackground }, wcagAANonText},
+}
+
+// ContrastIssue é um par de cores abaixo do mínimo AA
+type ContrastIssue struct {
+	Mode       string // light ou dark
+	Pair       string
+	Foreground string
+	Background string
+	Ratio      float64
+	Required   float64
+}
+
+func (i ContrastIssue) String() string {
+	return fmt.Sprintf("[%s] %s: %s sobre %s = %.2f:1 (mínimo AA %.1f:1)", i.Mode, i.Pair, i.Foreground, i.Background, i.Ratio, i.Required)
+}
+
+// contrastRatio calcula a razão de contraste WCAG 2.1 entre duas cores (1 a 21)
+func contrastRatio(a, b color.RGBA) float64 {
+	la, lb := relativeLuminance(a), relativeLuminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// AuditThemeContrast verifica os pares de cores das paletas light e dark.
+// Pares com alguma cor ausente ou inválida são ignorados.
+func AuditThemeContrast(light, dark ThemePalette) []ContrastIssue {
+	var issues []ContrastIssue
+	for _, mode := range []struct {
+		name    string
+		palette ThemePalette
+	}{{"light", light}, {"dark", dark}} {
+		for _, pair := range themeContrastPairs {
+			fgHex, bgHex := pair.foreground(mode.palette), pair.background(mode.palette)
+			fg, err := parseHexColor(fgHex)
+			if err != nil {
+				continue
+			}
+			bg, err := parseHexColor(bgHex)
+			if err != nil {
+				continue
+			}
+
+			if ratio := contrastRatio(fg, bg); ratio < pair.required {
+				issues = append(issues, ContrastIssue{
+					Mode:       mode.name,
+					Pair:       pair.name,
+					Foreground: fgHex,
+					Background: bgHex,
+					Ratio:      ratio,
+					Required:   pair.required,
+				})
+			}
+		}
+	}
+	return issues
+}
+
+// themePaletteFromPayload lê uma paleta do payload de tema do backend (chaves primary_color_light, ...)
+func themePaletteFromPayload(fields map[string]interface{}, suffix string) ThemePalette {
+	get := func(key string) string {
+		v, _ := fields[key+suffix].(string)
+		return v
+	}
+
+	return ThemePalette{
+		Primary:        get("primary_color"),
+		Secondary:      get("secondary_color"),
+		Background:     get("background_color"),
+		CardBackground: get("card_background_color"),
+		Text:           get("text_color"),
+		TextSecondary:  get("text_secondary_color"),
+		Accent:         get("accent_color"),
+		Success:        get("success_color"),
+		Error:          get("error_color"),
+		Warning:        get("warning_color"),
+		Info:           get("info_color"),
+	}
+}
+
+// logContrastIssues exibe os pares reprovados; retorna true se houver algum
+func logContrastIssues(logger *Logger, source string, issues []ContrastIssue) bool {
+	if len(issues) == 0 {
+		logger.Info("Contraste do tema (%s) atende WCAG AA", source)
+		return false
+	}
+
+	logger.Warn("Tema (%s) com %d par(es) abaixo do contraste WCAG AA:", source, len(issues))
+	for _, issue := range issues {
+		logger.Warn("  %s", issue)
+	}
+	return true
+}
+
+// auditSeedContrast audita o tema do seed e de cada projeto; retorna true se algum par foi reprovado
+func auditSeedContrast(logger *Logger, seedData *SeedData) bool {
+	failed := false
+
+	audit := func(source string, theme ThemeCustomizationData) {
+		if theme.PrimaryColor == "" {
+			return
+		}
+		if logContrastIssues(logger, source, AuditThemeContrast(theme.LightPalette(), theme.DarkPalette())) {
+			failed = true
+		}
+	}
+
+	audit("seed", seedData.ThemeCustomization)
+	for _, p := range seedData.Projects {
+		audit("seed, projeto "+p.Name, p.ThemeCustomization)
+	}
+
+	return failed
+}
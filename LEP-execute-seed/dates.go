@@ -0,0 +1,184 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Datas relativas aceitas no seed, resolvidas no fuso de settings.timezone:
+//
+//	today | tomorrow | yesterday | now | next <weekday> | <weekday>
+//	seguidas de deslocamentos opcionais (+2d, -1w, +3h, -30y, +1mo) e horário opcional (19:30)
+//
+// Exemplos: "today+2d 19:30", "next friday 20:00", "today-30y".
+// Valores que não começam com uma base relativa são mantidos como estão (datas absolutas).
+
+var relativeWeekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+}
+
+var (
+	relativeOffsetPattern = regexp.MustCompile(`^([+-]\d+)(mo|d|w|h|y)`)
+	relativeClockPattern  = regexp.MustCompile(`^(\d{1,2}):(\d{2})$`)
+)
+
+// ResolveRelativeDate interpreta uma data relativa a partir de now (já no fuso desejado).
+// Retorna ok=false quando a expressão não é relativa.
+func ResolveRelativeDate(expr string, now time.Time) (t time.Time, ok bool, err error) {
+	fields := strings.Fields(strings.ToLower(strings.TrimSpace(expr)))
+	if len(fields) == 0 {
+		return time.Time{}, false, nil
+	}
+
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	// Base: a primeira palavra, possivelmente colada aos deslocamentos ("today+2d")
+	head := fields[0]
+	rest := ""
+	if i := strings.IndexAny(head, "+-"); i > 0 {
+		head, rest = head[:i], head[i:]
+	}
+
+	switch head {
+	case "today":
+		t = midnight
+	case "tomorrow":
+		t = midnight.AddDate(0, 0, 1)
+	case "yesterday":
+		t = midnight.AddDate(0, 0, -1)
+	case "now":
+		t = now
+	case "next":
+		if len(fields) < 2 {
+			return time.Time{}, true, fmt.Errorf("data relativa %q: dia da semana ausente", expr)
+		}
+		fields = fields[1:]
+		head, rest = fields[0], ""
+		if i := strings.IndexAny(head, "+-"); i > 0 {
+			head, rest = head[:i], head[i:]
+		}
+		wd, known := relativeWeekdays[head]
+		if !known {
+			return time.Time{}, true, fmt.Errorf("data relativa %q: dia da semana desconhecido %q", expr, head)
+		}
+		t = nextWeekday(midnight, wd)
+	default:
+		wd, known := relativeWeekdays[head]
+		if !known {
+			return time.Time{}, false, nil
+		}
+		t = nextWeekday(midnight, wd)
+	}
+
+	// Deslocamentos podem vir colados à base ou como palavras separadas
+	tokens := append([]string{}, fields[1:]...)
+	if rest != "" {
+		tokens = append([]string{rest}, tokens...)
+	}
+
+	for _, token := range tokens {
+		if m := relativeClockPattern.FindStringSubmatch(token); m != nil {
+			hour, _ := strconv.Atoi(m[1])
+			minute, _ := strconv.Atoi(m[2])
+			if hour > 23 || minute > 59 {
+				return time.Time{}, true, fmt.Errorf("data relativa %q: horário inválido %q", expr, token)
+			}
+			t = time.Date(t.Year(), t.Month(), t.Day(), hour, minute, 0, 0, t.Location())
+			continue
+		}
+
+		for token != "" {
+			m := relativeOffsetPattern.FindStringSubmatch(token)
+			if m == nil {
+				return time.Time{}, true, fmt.Errorf("data relativa %q: trecho inválido %q", expr, token)
+			}
+			n, _ := strconv.Atoi(m[1])
+			switch m[2] {
+			case "d":
+				t = t.AddDate(0, 0, n)
+			case "w":
+				t = t.AddDate(0, 0, 7*n)
+			case "mo":
+				t = t.AddDate(0, n, 0)
+			case "y":
+				t = t.AddDate(n, 0, 0)
+			case "h":
+				t = t.Add(time.Duration(n) * time.Hour)
+			}
+			token = token[len(m[0]):]
+		}
+	}
+
+	return t, true, nil
+}
+
+// nextWeekday retorna a próxima ocorrência do dia da semana, sempre depois de hoje
+func nextWeekday(from time.Time, wd time.Weekday) time.Time {
+	days := (int(wd) - int(from.Weekday()) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+	return from.AddDate(0, 0, days)
+}
+
+// ResolveRelativeDates substitui as datas relativas do seed (reservas e aniversários de clientes)
+// por datas absolutas no fuso do projeto.
+// Projetos sem settings.timezone usam o fuso do nível superior.
+func (s *SeedData) ResolveRelativeDates(now time.Time) error {
+	if err := s.resolveRelativeDates(now, ""); err != nil {
+		return err
+	}
+
+	for i := range s.Projects {
+		if err := s.Projects[i].resolveRelativeDates(now, s.Settings.Timezone); err != nil {
+			return fmt.Errorf("projects[%d] (%s): %w", i, s.Projects[i].Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *SeedData) resolveRelativeDates(now time.Time, fallbackTimezone string) error {
+	timezone := s.Settings.Timezone
+	if timezone == "" {
+		timezone = fallbackTimezone
+	}
+
+	loc := time.Local
+	if timezone != "" {
+		var err error
+		loc, err = time.LoadLocation(timezone)
+		if err != nil {
+			return fmt.Errorf("settings.timezone inválido %q: %w", timezone, err)
+		}
+	}
+	now = now.In(loc)
+
+	resolve := func(field string, value *string, layout string) error {
+		t, ok, err := ResolveRelativeDate(*value, now)
+		if err != nil {
+			return fmt.Errorf("%s: %w", field, err)
+		}
+		if ok {
+			*value = t.Format(layout)
+		}
+		return nil
+	}
+
+	for i := range s.Reservations {
+		if err := resolve(fmt.Sprintf("reservations[%d].datetime", i), &s.Reservations[i].DateTime, time.RFC3339); err != nil {
+			return err
+		}
+	}
+	for i := range s.Customers {
+		if err := resolve(fmt.Sprintf("customers[%d].birth_date", i), &s.Customers[i].BirthDate, "2006-01-02"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResolveRelativeDates(t *testing.T) {
+	// Sexta-feira, 12:00 em São Paulo
+	now := time.Date(2026, 3, 13, 15, 0, 0, 0, time.UTC)
+
+	reservation := func(datetime string) []ReservationData {
+		return []ReservationData{{DateTime: datetime}}
+	}
+	saoPaulo := SettingsData{Timezone: "America/Sao_Paulo"}
+
+	tests := []struct {
+		name    string
+		seed    SeedData
+		get     func(s *SeedData) string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "reserva com deslocamento e horário",
+			seed: SeedData{Settings: saoPaulo, Reservations: reservation("today+2d 19:30")},
+			get:  func(s *SeedData) string { return s.Reservations[0].DateTime },
+			want: "2026-03-15T19:30:00-03:00",
+		},
+		{
+			name: "próximo dia da semana nunca é hoje",
+			seed: SeedData{Settings: saoPaulo, Reservations: reservation("next friday 20:00")},
+			get:  func(s *SeedData) string { return s.Reservations[0].DateTime },
+			want: "2026-03-20T20:00:00-03:00",
+		},
+		{
+			name: "aniversário sai só com a data",
+			seed: SeedData{Settings: saoPaulo, Customers: []CustomerData{{BirthDate: "today-30y+3d"}}},
+			get:  func(s *SeedData) string { return s.Customers[0].BirthDate },
+			want: "1996-03-16",
+		},
+		{
+			name: "data absoluta não muda",
+			seed: SeedData{Settings: saoPaulo, Reservations: reservation("2026-05-01T20:00:00Z")},
+			get:  func(s *SeedData) string { return s.Reservations[0].DateTime },
+			want: "2026-05-01T20:00:00Z",
+		},
+		{
+			name: "projeto sem timezone usa o do nível superior",
+			seed: SeedData{Settings: saoPaulo, Projects: []ProjectSeedData{
+				{Name: "Filial", SeedData: SeedData{Reservations: reservation("tomorrow 12:00")}},
+			}},
+			get:  func(s *SeedData) string { return s.Projects[0].Reservations[0].DateTime },
+			want: "2026-03-14T12:00:00-03:00",
+		},
+		{
+			name: "projeto com timezone próprio",
+			seed: SeedData{Settings: saoPaulo, Projects: []ProjectSeedData{
+				{Name: "Tóquio", SeedData: SeedData{Settings: SettingsData{Timezone: "Asia/Tokyo"}, Reservations: reservation("tomorrow")}},
+			}},
+			get:  func(s *SeedData) string { return s.Projects[0].Reservations[0].DateTime },
+			want: "2026-03-15T00:00:00+09:00",
+		},
+		{
+			name:    "expressão inválida",
+			seed:    SeedData{Settings: saoPaulo, Reservations: reservation("today+2x")},
+			wantErr: "reservations[0].datetime",
+		},
+		{
+			name:    "horário inválido",
+			seed:    SeedData{Settings: saoPaulo, Reservations: reservation("today 25:00")},
+			wantErr: "horário inválido",
+		},
+		{
+			name:    "timezone inválido",
+			seed:    SeedData{Settings: SettingsData{Timezone: "Marte/Olympus"}, Reservations: reservation("today")},
+			wantErr: "settings.timezone inválido",
+		},
+		{
+			name: "erro em projeto indica o projeto",
+			seed: SeedData{Projects: []ProjectSeedData{
+				{Name: "Filial", SeedData: SeedData{Customers: []CustomerData{{BirthDate: "next someday"}}}},
+			}},
+			wantErr: "projects[0] (Filial)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.seed.ResolveRelativeDates(now)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ResolveRelativeDates() erro = %v, want contendo %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveRelativeDates() erro inesperado: %v", err)
+			}
+			if got := tt.get(&tt.seed); got != tt.want {
+				t.Errorf("ResolveRelativeDates() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
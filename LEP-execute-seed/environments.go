@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+)
+
+// EnvironmentProfile é um alvo nomeado em environments (local, staging, prod)
+type EnvironmentProfile struct {
+	URL       string `yaml:"url"`
+	Protected bool   `yaml:"protected"` // deleções exigem -allow-prod-deletes
+}
+
+// applyEnvironment aplica o perfil escolhido com -env. Uma server.url vinda de flag ou
+// variável de ambiente só é aceita se apontar para o mesmo alvo do perfil.
+func (c *Config) applyEnvironment(sources configSources) error {
+	if c.Env == "" {
+		return nil
+	}
+
+	profile, ok := c.Environments[c.Env]
+	if !ok {
+		return fmt.Errorf("ambiente %q não definido em environments (disponíveis: %s)", c.Env, strings.Join(c.environmentNames(), ", "))
+	}
+	if profile.URL == "" {
+		return fmt.Errorf("environments.%s.url não definido", c.Env)
+	}
+	source := sources["server.url"]
+	explicit := strings.HasPrefix(source, "flag ") || strings.HasPrefix(source, "env ")
+	if explicit && strings.TrimRight(c.Server.URL, "/") != strings.TrimRight(profile.URL, "/") {
+		return fmt.Errorf("server.url %s (%s) diverge do ambiente %s (%s); use apenas um dos dois", c.Server.URL, source, c.Env, profile.URL)
+	}
+
+	c.Server.URL = profile.URL
+	sources["server.url"] = "environments." + c.Env + " (flag -env)"
+	return nil
+}
+
+func (c *Config) environmentNames() []string {
+	var names []string
+	for name := range c.Environments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// protectedTarget indica se o ambiente selecionado bloqueia deleções
+func (c *Config) protectedTarget() bool {
+	return c.Env != "" && c.Environments[c.Env].Protected
+}
+
+// CheckDeletesAllowed bloqueia operações que apagam dados em ambiente protegido sem -allow-prod-deletes
+func (c *Config) CheckDeletesAllowed(operation string) error {
+	if !c.protectedTarget() || c.AllowProdDeletes {
+		return nil
+	}
+	return fmt.Errorf("%s apaga dados e o ambiente %s é protegido; passe também -allow-prod-deletes", operation, c.Env)
+}
+
+// isLocalURL indica se a URL aponta para a própria máquina
+func isLocalURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	switch u.Hostname() {
+	case "localhost", "127.0.0.1", "::1":
+		return true
+	}
+	return false
+}
+
+// guardTarget impede que um alvo remoto seja usado por engano: exige -env e que o nome
+// da organização seja digitado; com ambiente protegido, deleções exigem -allow-prod-deletes
+func guardTarget(config *Config, logger *Logger, in io.Reader) error {
+	if config.Seed.PruneLinks {
+		if err := config.CheckDeletesAllowed("-prune-links"); err != nil {
+			return err
+		}
+	}
+	if config.Sync.Enabled && config.Sync.Confirm && len(config.Sync.Prune) > 0 {
+		if err := config.CheckDeletesAllowed("-sync -yes"); err != nil {
+			return err
+		}
+	}
+
+	if isLocalURL(config.Server.URL) {
+		return nil
+	}
+	if config.Env == "" {
+		return fmt.Errorf("alvo remoto %s exige -env (ambientes: %s)", config.Server.URL, strings.Join(config.environmentNames(), ", "))
+	}
+
+	if config.Auth.OrganizationName == "" {
+		return fmt.Errorf("alvo remoto exige auth.organization_name (-org) para a confirmação")
+	}
+
+	logger.Warn("Alvo remoto: %s (ambiente %s)", config.Server.URL, config.Env)
+	fmt.Fprintf(os.Stderr, "Digite o nome da organização (%s) para continuar: ", config.Auth.OrganizationName)
+
+	typed, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("erro ao ler confirmação: %w", err)
+	}
+	if strings.TrimSpace(typed) != config.Auth.OrganizationName {
+		return fmt.Errorf("confirmação não confere com a organização %q, nada foi executado", config.Auth.OrganizationName)
+	}
+	return nil
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// idLedger registra o ID do backend de cada entidade declarada com external_id.
+// O backend não tem campo para o external_id, então o vínculo fica neste arquivo local
+// (seed.id_ledger) e sobrevive a renomeações no seed.
+type idLedger struct {
+	path    string
+	Entries map[string]string `json:"entries"` // projID:entity:external_id -> ID
+	dirty   bool
+}
+
+// loadIDLedger carrega o ledger de IDs; arquivo inexistente resulta em ledger vazio
+func loadIDLedger(path string) (*idLedger, error) {
+	ledger := &idLedger{path: path, Entries: make(map[string]string)}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ledger, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler ledger de IDs: %w", err)
+	}
+
+	if err := json.Unmarshal(data, ledger); err != nil {
+		return nil, fmt.Errorf("erro ao parsear ledger de IDs: %w", err)
+	}
+	if ledger.Entries == nil {
+		ledger.Entries = make(map[string]string)
+	}
+
+	return ledger, nil
+}
+
+// save grava o ledger se houve alteração
+func (l *idLedger) save() error {
+	if l == nil || !l.dirty {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("erro ao serializar ledger de IDs: %w", err)
+	}
+
+	if err := os.WriteFile(l.path, data, 0644); err != nil {
+		return fmt.Errorf("erro ao gravar ledger de IDs: %w", err)
+	}
+
+	l.dirty = false
+	return nil
+}
+
+func (l *idLedger) key(projID, entity, externalID string) string {
+	return projID + ":" + entity + ":" + externalID
+}
+
+// seedEntity identifica uma entidade do seed para casamento com o backend
+type seedEntity struct {
+	kind       string      // menu, category, ... (tipo no ledger e nos erros)
+	path       string      // coleção no backend
+	keyField   string      // chave natural: name, number, email, confirmation_key
+	key        interface{} // valor da chave natural no seed
+	externalID string
+}
+
+func (e seedEntity) keyString() string {
+	value, _ := cacheKeyValue(e.key)
+	return value
+}
+
+// entityMatch é o resultado de matchEntity
+type entityMatch struct {
+	id      uuid.UUID // uuid.Nil quando a entidade precisa ser criada
+	renamed bool      // a entidade foi renomeada (ou a tentativa falhou) e já entrou na contagem
+}
+
+// matchEntity procura a entidade no backend. Com external_id, o ledger é consultado antes da
+// chave natural: se a chave mudou no seed, a entidade existente é atualizada em vez de duplicada.
+// Mais de uma entidade com a mesma chave no backend é reportada como duplicata, com ou sem external_id.
+func (s *SeedServiceV2) matchEntity(ctx context.Context, e seedEntity) entityMatch {
+	if e.externalID != "" {
+		if ledger := s.idLedger(); ledger != nil {
+			if id, ok := ledger.Entries[ledger.key(s.client.projID, e.kind, e.externalID)]; ok {
+				if match, ok := s.matchLedgerEntity(ctx, e, id); ok {
+					return match
+				}
+			}
+		}
+	}
+
+	ids, err := s.client.findAllByField(ctx, e.path, e.keyField, e.keyString())
+	if err != nil || len(ids) == 0 {
+		return entityMatch{}
+	}
+	if len(ids) > 1 {
+		s.reportDuplicates(e, ids, ids[0])
+	}
+
+	id, err := uuid.Parse(ids[0])
+	if err != nil {
+		return entityMatch{}
+	}
+	s.rememberExternalID(e, id)
+	return entityMatch{id: id}
+}
+
+// matchLedgerEntity confere a entidade registrada no ledger; ok=false quando ela não existe mais
+func (s *SeedServiceV2) matchLedgerEntity(ctx context.Context, e seedEntity, id string) (entityMatch, bool) {
+	current, err := s.client.findByID(ctx, e.path, id)
+	if err != nil || current == nil {
+		s.logger.Debug("%s %s: entidade %s do ledger não encontrada, casando pela chave natural", e.kind, e.externalID, id)
+		return entityMatch{}, false
+	}
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return entityMatch{}, false
+	}
+
+	currentKey, _ := cacheKeyValue(current[e.keyField])
+	if currentKey == e.keyString() {
+		if same, err := s.client.findAllByField(ctx, e.path, e.keyField, e.keyString()); err == nil && len(same) > 1 {
+			s.reportDuplicates(e, same, id)
+		}
+		return entityMatch{id: parsed}, true
+	}
+
+	// Renomeação: a nova chave não pode pertencer a outra entidade
+	others, err := s.client.findAllByField(ctx, e.path, e.keyField, e.keyString())
+	if err == nil && len(others) > 0 {
+		err = fmt.Errorf("external_id %s aponta para %q, mas %s %q já existe com outro ID (%s)", e.externalID, currentKey, e.kind, e.keyString(), strings.Join(others, ", "))
+	}
+	if err == nil {
+		err = s.client.UpdateEntity(ctx, e.path, id, mergeFields(current, map[string]interface{}{e.keyField: e.key}))
+	}
+	if err != nil {
+		s.logger.With(Fields{"entity": e.kind}).Error("Erro ao renomear %s %s -> %s: %v", e.kind, currentKey, e.keyString(), err)
+		s.state.failed++
+		s.state.errors = append(s.state.errors, newSeedError(e.kind, e.keyString(), err))
+		return entityMatch{id: parsed, renamed: true}, true
+	}
+
+	s.logger.Info("Renomeado: %s %s -> %s (external_id %s)", e.kind, currentKey, e.keyString(), e.externalID)
+	s.state.updated++
+	return entityMatch{id: parsed, renamed: true}, true
+}
+
+// rememberExternalID registra no ledger o ID da entidade declarada com external_id
+func (s *SeedServiceV2) rememberExternalID(e seedEntity, id uuid.UUID) {
+	if e.externalID == "" || id == uuid.Nil {
+		return
+	}
+	ledger := s.idLedger()
+	if ledger == nil {
+		return
+	}
+
+	key := ledger.key(s.client.projID, e.kind, e.externalID)
+	if ledger.Entries[key] != id.String() {
+		ledger.Entries[key] = id.String()
+		ledger.dirty = true
+	}
+}
+
+// reportDuplicates registra como aviso as entidades do backend com a mesma chave natural
+func (s *SeedServiceV2) reportDuplicates(e seedEntity, ids []string, used string) {
+	err := fmt.Errorf("%d entidades com %s %q no backend (%s); usando %s", len(ids), e.keyField, e.keyString(), strings.Join(ids, ", "), used)
+	s.logger.With(Fields{"entity": e.kind}).Warn("Duplicata: %s %s: %v", e.kind, e.keyString(), err)
+	s.state.warnings = append(s.state.warnings, newSeedError("duplicate", e.kind+" "+e.keyString(), err))
+}
+
+// idLedger carrega o ledger de IDs uma vez por arquivo de seed; se falhar, o casamento é só pela chave natural
+func (s *SeedServiceV2) idLedger() *idLedger {
+	if s.state.ids != nil || s.state.idsFailed {
+		return s.state.ids
+	}
+
+	ledger, err := loadIDLedger(s.config.Seed.IDLedger)
+	if err != nil {
+		s.recordStepError("external_id", s.config.Seed.IDLedger, err)
+		s.state.idsFailed = true
+		return nil
+	}
+	s.state.ids = ledger
+	return ledger
+}
+
+// saveIDLedger grava o ledger de IDs ao final do arquivo de seed
+func (s *SeedServiceV2) saveIDLedger() {
+	if err := s.state.ids.save(); err != nil {
+		s.logger.Error("%v", err)
+	}
+}
+
+// findByID retorna a entidade da coleção com o ID informado, ou nil se ela não existe mais
+func (c *APIClientV2) findByID(ctx context.Context, path, id string) (map[string]interface{}, error) {
+	items, err := c.entities(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if itemID, _ := item["id"].(string); itemID == id {
+			return item, nil
+		}
+	}
+	return nil, nil
+}
+
+// findAllByField retorna os IDs de todas as entidades cujo campo tem o valor informado.
+// Sem cache, o filtro do backend é usado quando existe; o valor é sempre conferido.
+func (c *APIClientV2) findAllByField(ctx context.Context, path, field, value string) ([]string, error) {
+	var items []map[string]interface{}
+	var err error
+	if param, ok := lookupFilters[path][field]; ok && !c.cache.enabled {
+		items, err = c.listAll(ctx, path, url.Values{param: []string{value}})
+	} else {
+		items, err = c.entities(ctx, path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, item := range items {
+		v, ok := cacheKeyValue(item[field])
+		id, _ := item["id"].(string)
+		if ok && v == value && id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// entities lista a coleção inteira, do cache quando ativo
+func (c *APIClientV2) entities(ctx context.Context, path string) ([]map[string]interface{}, error) {
+	if !c.cache.enabled {
+		return c.listAll(ctx, path, nil)
+	}
+	col, err := c.collection(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return col.items, nil
+}
+
+// ValidateExternalIDs exige external_id único por tipo de entidade
+func (s *SeedData) ValidateExternalIDs() error {
+	sections := []struct {
+		name string
+		ids  []string
+	}{
+		{"menus", externalIDs(len(s.Menus), func(i int) string { return s.Menus[i].ExternalID })},
+		{"categories", externalIDs(len(s.Categories), func(i int) string { return s.Categories[i].ExternalID })},
+		{"subcategories", externalIDs(len(s.Subcategories), func(i int) string { return s.Subcategories[i].ExternalID })},
+		{"environments", externalIDs(len(s.Environments), func(i int) string { return s.Environments[i].ExternalID })},
+		{"tables", externalIDs(len(s.Tables), func(i int) string { return s.Tables[i].ExternalID })},
+		{"products", externalIDs(len(s.Products), func(i int) string { return s.Products[i].ExternalID })},
+		{"users", externalIDs(len(s.Users), func(i int) string { return s.Users[i].ExternalID })},
+		{"customers", externalIDs(len(s.Customers), func(i int) string { return s.Customers[i].ExternalID })},
+		{"tags", externalIDs(len(s.Tags), func(i int) string { return s.Tags[i].ExternalID })},
+		{"reservations", externalIDs(len(s.Reservations), func(i int) string { return s.Reservations[i].ExternalID })},
+		{"notification_templates", externalIDs(len(s.NotificationTemplates), func(i int) string { return s.NotificationTemplates[i].ExternalID })},
+		{"projects", externalIDs(len(s.Projects), func(i int) string { return s.Projects[i].ExternalID })},
+	}
+
+	for _, section := range sections {
+		seen := make(map[string]int, len(section.ids))
+		for i, id := range section.ids {
+			if id == "" {
+				continue
+			}
+			if first, ok := seen[id]; ok {
+				return fmt.Errorf("%s[%d]: external_id %q já usado em %s[%d]", section.name, i, id, section.name, first)
+			}
+			seen[id] = i
+		}
+	}
+	return nil
+}
+
+func externalIDs(n int, get func(i int) string) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = get(i)
+	}
+	return ids
+}
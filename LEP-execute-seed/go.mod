@@ -5,4 +5,8 @@ go 1.24
 require (
 	github.com/google/uuid v1.5.0
 	gopkg.in/yaml.v2 v2.4.0
+	lep-shared v0.0.0
 )
+
+// Código comum com LEP-teste-back (logger e política de retry)
+replace lep-shared => ../LEP-shared
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
+)
+
+// imageUploadEntities mapeia a entidade (CRUD) para o segmento do endpoint de upload
+// Menus não têm rota de upload no backend, então não recebem imagem.
+var imageUploadEntities = map[string]string{
+	"category": "categories",
+	"product":  "products",
+}
+
+// imageLedger registra o hash da última imagem enviada por entidade,
+// para que re-execuções não reenviem imagens que não mudaram
+type imageLedger struct {
+	path    string
+	Entries map[string]imageLedgerEntry `json:"entries"` // projID:entity:id -> entrada
+	dirty   bool
+}
+
+type imageLedgerEntry struct {
+	Hash     string `json:"hash"`
+	ImageURL string `json:"image_url"`
+}
+
+// loadImageLedger carrega o ledger de imagens; arquivo inexistente resulta em ledger vazio
+func loadImageLedger(path string) (*imageLedger, error) {
+	ledger := &imageLedger{path: path, Entries: make(map[string]imageLedgerEntry)}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ledger, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler ledger de imagens: %w", err)
+	}
+
+	if err := json.Unmarshal(data, ledger); err != nil {
+		return nil, fmt.Errorf("erro ao parsear ledger de imagens: %w", err)
+	}
+	if ledger.Entries == nil {
+		ledger.Entries = make(map[string]imageLedgerEntry)
+	}
+
+	return ledger, nil
+}
+
+// save grava o ledger se houve alteração
+func (l *imageLedger) save() error {
+	if !l.dirty {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("erro ao serializar ledger de imagens: %w", err)
+	}
+
+	if err := os.WriteFile(l.path, data, 0644); err != nil {
+		return fmt.Errorf("erro ao gravar ledger de imagens: %w", err)
+	}
+
+	l.dirty = false
+	return nil
+}
+
+func (l *imageLedger) key(projID, entity, id string) string {
+	return projID + ":" + entity + ":" + id
+}
+
+// imageJob é uma imagem a ser enviada para uma entidade já criada
+type imageJob struct {
+	entity      string // category, product
+	id          string
+	name        string
+	path        string // foto declarada no seed; vazio para placeholder gerado
+	placeholder bool
+}
+
+// seedImages envia as imagens declaradas em categorias e produtos (Passo 8b).
+// Com seed.placeholder_images, categorias e produtos sem foto recebem uma imagem gerada com suas iniciais.
+func (s *SeedServiceV2) seedImages(ctx context.Context, categoryIDs, productIDs map[int]string) {
+	var jobs []imageJob
+	placeholders := s.config.Seed.PlaceholderImages
+
+	for _, menu := range s.seedData.Menus {
+		if menu.ImagePath != "" {
+			s.logger.Warn("Menu %s: image_path ignorado, o backend não tem upload de imagem de menu", menu.Name)
+		}
+	}
+	for idx, cat := range s.seedData.Categories {
+		if id, ok := categoryIDs[idx]; ok && (cat.ImagePath != "" || placeholders) {
+			jobs = append(jobs, imageJob{entity: "category", id: id, name: cat.Name, path: cat.ImagePath, placeholder: cat.ImagePath == ""})
+		}
+	}
+	for idx, prod := range s.seedData.Products {
+		if id, ok := productIDs[idx]; ok && (prod.ImagePath != "" || placeholders) {
+			jobs = append(jobs, imageJob{entity: "product", id: id, name: prod.Name, path: prod.ImagePath, placeholder: prod.ImagePath == ""})
+		}
+	}
+
+	if len(jobs) == 0 {
+		s.logger.Info("Nenhuma imagem definida no seed")
+		return
+	}
+
+	ledger, err := loadImageLedger(s.config.Seed.ImageLedger)
+	if err != nil {
+		s.logger.Error("%v", err)
+		s.state.failed++
+		return
+	}
+
+	palette := placeholderPalette(s.seedData.ThemeCustomization)
+	for _, job := range jobs {
+		if s.interrupted(ctx) {
+			break
+		}
+
+		s.uploadEntityImage(ctx, ledger, job, palette)
+	}
+
+	if err := ledger.save(); err != nil {
+		s.logger.Error("%v", err)
+	}
+}
+
+// uploadEntityImage envia e associa a imagem da entidade, pulando se o conteúdo não mudou
+func (s *SeedServiceV2) uploadEntityImage(ctx context.Context, ledger *imageLedger, job imageJob, palette []color.RGBA) {
+	data, fileName, err := s.loadJobImage(job, palette)
+	if err != nil {
+		s.recordImageError(job, err)
+		return
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	key := ledger.key(s.client.projID, job.entity, job.id)
+
+	if entry, ok := ledger.Entries[key]; ok && entry.Hash == hash {
+		s.logger.Info("Imagem de %s %s não mudou", job.entity, job.name)
+		s.state.skipped++
+		return
+	}
+
+	imageURL, err := s.client.UploadImage(ctx, imageUploadEntities[job.entity], fileName, data)
+	if err != nil {
+		s.recordImageError(job, err)
+		return
+	}
+
+	if err := s.client.SetEntityImage(ctx, job.entity, job.id, imageURL); err != nil {
+		s.recordImageError(job, fmt.Errorf("upload ok, erro ao associar image_url: %w", err))
+		return
+	}
+
+	ledger.Entries[key] = imageLedgerEntry{Hash: hash, ImageURL: imageURL}
+	ledger.dirty = true
+
+	if job.placeholder {
+		s.logger.Info("Placeholder enviado: %s %s", job.entity, job.name)
+	} else {
+		s.logger.Info("Imagem enviada: %s %s", job.entity, job.name)
+	}
+	s.state.created++
+}
+
+// loadJobImage lê a foto do seed (relativa ao arquivo de seed) ou gera o placeholder
+func (s *SeedServiceV2) loadJobImage(job imageJob, palette []color.RGBA) ([]byte, string, error) {
+	if job.placeholder {
+		data, err := GeneratePlaceholderPNG(job.name, placeholderSizes[job.entity], palette)
+		if err != nil {
+			return nil, "", fmt.Errorf("erro ao gerar placeholder: %w", err)
+		}
+		return data, fmt.Sprintf("placeholder-%s-%s.png", job.entity, job.id), nil
+	}
+
+	fullPath := job.path
+	if !filepath.IsAbs(fullPath) {
+		fullPath = filepath.Join(filepath.Dir(s.seedFile), fullPath)
+	}
+
+	data, err := os.ReadFile(fullPath)
+	if err != nil {
+		return nil, "", fmt.Errorf("erro ao ler imagem: %w", err)
+	}
+	return data, filepath.Base(fullPath), nil
+}
+
+func (s *SeedServiceV2) recordImageError(job imageJob, err error) {
+	s.logger.With(Fields{"entity": job.entity}).Error("Erro na imagem de %s %s: %v", job.entity, job.name, err)
+	s.state.failed++
+	s.state.errors = append(s.state.errors, newSeedError("image", job.name, err))
+}
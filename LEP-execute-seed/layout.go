@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Severidades aceitas em lint.layout
+const (
+	lintError   = "error"
+	lintWarning = "warning"
+	lintOff     = "off"
+)
+
+// layoutRules são as regras do lint de salão e suas severidades padrão
+var layoutRules = map[string]string{
+	"capacity_sum":          lintWarning, // mesas somam mais lugares que a capacidade do ambiente
+	"duplicate_table":       lintError,   // mesmo número de mesa declarado mais de uma vez
+	"non_positive_capacity": lintError,   // ambiente ou mesa com capacidade <= 0
+	"orphan_table":          lintError,   // mesa aponta para um ambiente não declarado (environment_id_ref)
+	"party_size":            lintWarning, // reserva com mais pessoas que a capacidade da mesa
+}
+
+// LayoutIssue é um problema de capacidade ou de layout encontrado no seed
+type LayoutIssue struct {
+	Rule     string
+	Severity string
+	Item     string
+	Message  string
+}
+
+func (i LayoutIssue) String() string {
+	return fmt.Sprintf("[%s] %s: %s", i.Rule, i.Item, i.Message)
+}
+
+// layoutSeverity retorna a severidade configurada para a regra, ou a padrão.
+// Com strict, avisos viram erros.
+func layoutSeverity(overrides map[string]string, strict bool, rule string) string {
+	severity := layoutRules[rule]
+	if v, ok := overrides[rule]; ok {
+		severity = v
+	}
+	if strict && severity == lintWarning {
+		return lintError
+	}
+	return severity
+}
+
+// ValidateLayoutConfig verifica regras e severidades de lint.layout
+func ValidateLayoutConfig(overrides map[string]string) error {
+	for rule, severity := range overrides {
+		if _, ok := layoutRules[rule]; !ok {
+			return fmt.Errorf("lint.layout: regra %q desconhecida", rule)
+		}
+		if severity != lintError && severity != lintWarning && severity != lintOff {
+			return fmt.Errorf("lint.layout.%s: severidade %q inválida (use error, warning ou off)", rule, severity)
+		}
+	}
+	return nil
+}
+
+// LintLayout verifica ambientes, mesas e reservas do seed (sem sub-projetos)
+func (s *SeedData) LintLayout(overrides map[string]string, strict bool) []LayoutIssue {
+	var issues []LayoutIssue
+	report := func(rule, item, format string, args ...interface{}) {
+		severity := layoutSeverity(overrides, strict, rule)
+		if severity == lintOff {
+			return
+		}
+		issues = append(issues, LayoutIssue{Rule: rule, Severity: severity, Item: item, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for _, env := range s.Environments {
+		if env.Capacity <= 0 {
+			report("non_positive_capacity", "ambiente "+env.Name, "capacidade %d", env.Capacity)
+		}
+	}
+
+	seats := make(map[int]int, len(s.Environments))
+	numbers := make(map[int][]string)
+	for i, tbl := range s.Tables {
+		item := fmt.Sprintf("mesa %d", tbl.Number)
+		if tbl.Capacity <= 0 {
+			report("non_positive_capacity", item, "capacidade %d", tbl.Capacity)
+		}
+
+		envName := "sem ambiente"
+		switch {
+		case tbl.EnvironmentIDRef >= 0 && tbl.EnvironmentIDRef < len(s.Environments):
+			envName = s.Environments[tbl.EnvironmentIDRef].Name
+			if tbl.Capacity > 0 {
+				seats[tbl.EnvironmentIDRef] += tbl.Capacity
+			}
+		case len(s.Environments) > 0:
+			// Sem ambientes no seed as mesas são criadas sem ambiente; só é erro apontar para um que não existe
+			report("orphan_table", item, "environment_id_ref %d não aponta para nenhum dos %d ambientes", tbl.EnvironmentIDRef, len(s.Environments))
+		}
+		numbers[tbl.Number] = append(numbers[tbl.Number], fmt.Sprintf("tables[%d] (%s)", i, envName))
+	}
+
+	for idx, env := range s.Environments {
+		if env.Capacity > 0 && seats[idx] > env.Capacity {
+			report("capacity_sum", "ambiente "+env.Name, "mesas somam %d lugares, capacidade é %d", seats[idx], env.Capacity)
+		}
+	}
+
+	var dupNumbers []int
+	for number, where := range numbers {
+		if len(where) > 1 {
+			dupNumbers = append(dupNumbers, number)
+		}
+	}
+	sort.Ints(dupNumbers)
+	for _, number := range dupNumbers {
+		report("duplicate_table", fmt.Sprintf("mesa %d", number), "declarada %d vezes: %s", len(numbers[number]), strings.Join(numbers[number], ", "))
+	}
+
+	for i, res := range s.Reservations {
+		if res.TableIDRef < 0 || res.TableIDRef >= len(s.Tables) {
+			continue // referência inválida já é reportada ao criar a reserva
+		}
+		tbl := s.Tables[res.TableIDRef]
+		if tbl.Capacity > 0 && res.PartySize > tbl.Capacity {
+			report("party_size", fmt.Sprintf("reservations[%d]", i), "%d pessoas na mesa %d, que tem %d lugares", res.PartySize, tbl.Number, tbl.Capacity)
+		}
+	}
+
+	return issues
+}
+
+// lintSeedLayout roda o lint no seed e em cada projeto; retorna true se houver erro
+func lintSeedLayout(logger *Logger, seedData *SeedData, overrides map[string]string, strict bool) bool {
+	failed := false
+
+	lint := func(source string, data *SeedData) {
+		for _, issue := range data.LintLayout(overrides, strict) {
+			if issue.Severity == lintError {
+				logger.Error("Layout (%s) %s", source, issue)
+				failed = true
+			} else {
+				logger.Warn("Layout (%s) %s", source, issue)
+			}
+		}
+	}
+
+	lint("seed", seedData)
+	for i := range seedData.Projects {
+		lint("projeto "+seedData.Projects[i].Name, &seedData.Projects[i].SeedData)
+	}
+
+	return failed
+}
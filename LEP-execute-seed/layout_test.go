@@ -0,0 +1,125 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLintLayout(t *testing.T) {
+	salao := EnvironmentData{Name: "Salão", Capacity: 10}
+	varanda := EnvironmentData{Name: "Varanda", Capacity: 8}
+
+	tests := []struct {
+		name      string
+		seed      SeedData
+		overrides map[string]string
+		strict    bool
+		want      []string // regra/severidade/item
+	}{
+		{
+			name: "layout válido",
+			seed: SeedData{
+				Environments: []EnvironmentData{salao},
+				Tables:       []TableData{{Number: 1, Capacity: 4}, {Number: 2, Capacity: 6}},
+				Reservations: []ReservationData{{TableIDRef: 0, PartySize: 4}},
+			},
+		},
+		{
+			name: "capacidade não positiva",
+			seed: SeedData{
+				Environments: []EnvironmentData{{Name: "Terraço", Capacity: 0}},
+				Tables:       []TableData{{Number: 1, Capacity: -2}},
+			},
+			want: []string{
+				"non_positive_capacity/error/ambiente Terraço",
+				"non_positive_capacity/error/mesa 1",
+			},
+		},
+		{
+			name: "mesa sem ambiente",
+			seed: SeedData{
+				Environments: []EnvironmentData{salao},
+				Tables:       []TableData{{Number: 7, Capacity: 2, EnvironmentIDRef: 3}},
+			},
+			want: []string{"orphan_table/error/mesa 7"},
+		},
+		{
+			name: "seed sem ambientes cria mesas sem ambiente",
+			seed: SeedData{
+				Tables: []TableData{{Number: 1, Capacity: 4}, {Number: 2, Capacity: 2}},
+			},
+		},
+		{
+			name: "mesas excedem a capacidade do ambiente",
+			seed: SeedData{
+				Environments: []EnvironmentData{salao, varanda},
+				Tables: []TableData{
+					{Number: 1, Capacity: 6},
+					{Number: 2, Capacity: 6},
+					{Number: 3, Capacity: 8, EnvironmentIDRef: 1},
+				},
+			},
+			want: []string{"capacity_sum/warning/ambiente Salão"},
+		},
+		{
+			name: "número de mesa repetido entre ambientes",
+			seed: SeedData{
+				Environments: []EnvironmentData{salao, varanda},
+				Tables: []TableData{
+					{Number: 12, Capacity: 2},
+					{Number: 12, Capacity: 2, EnvironmentIDRef: 1},
+					{Number: 3, Capacity: 2},
+					{Number: 3, Capacity: 2},
+				},
+			},
+			want: []string{
+				"duplicate_table/error/mesa 3",
+				"duplicate_table/error/mesa 12",
+			},
+		},
+		{
+			name: "reserva maior que a mesa",
+			seed: SeedData{
+				Environments: []EnvironmentData{salao},
+				Tables:       []TableData{{Number: 1, Capacity: 4}},
+				Reservations: []ReservationData{{TableIDRef: 0, PartySize: 6}, {TableIDRef: 5, PartySize: 20}},
+			},
+			want: []string{"party_size/warning/reservations[0]"},
+		},
+		{
+			name: "strict transforma avisos em erros",
+			seed: SeedData{
+				Environments: []EnvironmentData{salao},
+				Tables:       []TableData{{Number: 1, Capacity: 4}},
+				Reservations: []ReservationData{{TableIDRef: 0, PartySize: 6}},
+			},
+			strict: true,
+			want:   []string{"party_size/error/reservations[0]"},
+		},
+		{
+			name: "regra desligada e severidade sobrescrita",
+			seed: SeedData{
+				Environments: []EnvironmentData{salao},
+				Tables:       []TableData{{Number: 1, Capacity: 4}, {Number: 1, Capacity: 8}},
+				Reservations: []ReservationData{{TableIDRef: 0, PartySize: 6}},
+			},
+			overrides: map[string]string{"duplicate_table": "off", "capacity_sum": "error"},
+			want: []string{
+				"capacity_sum/error/ambiente Salão",
+				"party_size/warning/reservations[0]",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, issue := range tt.seed.LintLayout(tt.overrides, tt.strict) {
+				got = append(got, issue.Rule+"/"+issue.Severity+"/"+issue.Item)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LintLayout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
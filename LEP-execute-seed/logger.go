@@ -1,68 +1,79 @@
 package main
 
 import (
-	"fmt"
 	"time"
-)
 
-type Logger struct {
-	verbose bool
-}
-
-const (
-	colorReset   = "\033[0m"
-	colorBold    = "\033[1m"
-	colorGreen   = "\033[32m"
-	colorRed     = "\033[31m"
-	colorYellow  = "\033[33m"
-	colorBlue    = "\033[34m"
-	colorCyan    = "\033[36m"
+	"lep-shared/logging"
 )
 
-func NewLogger(verbose bool) *Logger {
-	return &Logger{verbose: verbose}
-}
-
-func (l *Logger) Info(format string, args ...interface{}) {
-	fmt.Printf("%s[ℹ]%s %s\n", colorBlue, colorReset, fmt.Sprintf(format, args...))
-}
-
-func (l *Logger) Success(format string, args ...interface{}) {
-	fmt.Printf("%s[✓]%s %s\n", colorGreen, colorReset, fmt.Sprintf(format, args...))
-}
+// Fields são campos estruturados da entrada (step, entity, method, path, status, duration_ms)
+type Fields = logging.Fields
 
-func (l *Logger) Error(format string, args ...interface{}) {
-	fmt.Printf("%s[✗]%s %s\n", colorRed, colorReset, fmt.Sprintf(format, args...))
+// Logger é o logger comum (lep-shared/logging) com os cabeçalhos e o resumo do seeder
+type Logger struct {
+	*logging.Logger
 }
 
-func (l *Logger) Warn(format string, args ...interface{}) {
-	fmt.Printf("%s[⚠]%s %s\n", colorYellow, colorReset, fmt.Sprintf(format, args...))
+// NewLogger cria um logger de texto no stdout, com nível debug quando verbose
+func NewLogger(verbose bool) *Logger {
+	level := logging.LevelInfo
+	if verbose {
+		level = logging.LevelDebug
+	}
+	logger, _ := NewLoggerWithOptions(logging.Options{Level: level})
+	return logger
 }
 
-func (l *Logger) Skip(format string, args ...interface{}) {
-	fmt.Printf("%s[⏭]%s %s\n", colorCyan, colorReset, fmt.Sprintf(format, args...))
+// NewLoggerWithOptions cria o logger; falha apenas se o arquivo de log não puder ser aberto
+func NewLoggerWithOptions(opts logging.Options) (*Logger, error) {
+	logger, err := logging.New(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Logger{logger}, nil
 }
 
-func (l *Logger) Debug(format string, args ...interface{}) {
-	if l.verbose {
-		fmt.Printf("%s[D]%s %s\n", colorYellow, colorReset, fmt.Sprintf(format, args...))
-	}
+// With retorna um logger que anexa os campos a todas as entradas
+func (l *Logger) With(fields Fields) *Logger {
+	return &Logger{l.Logger.With(fields)}
 }
 
 func (l *Logger) Section(title string) {
-	fmt.Printf("\n%s%s========== %s ==========%s\n", colorBold, colorBlue, title, colorReset)
+	if l.JSON() {
+		l.Event(logging.LevelInfo, "section", title, nil)
+		return
+	}
+	l.Printf(logging.LevelInfo, "\n%s========== %s ==========%s\n", l.Color(logging.ColorBold+logging.ColorBlue), title, l.Color(logging.ColorReset))
 }
 
 func (l *Logger) Subsection(title string) {
-	fmt.Printf("\n%s>>> %s%s\n", colorCyan, title, colorReset)
+	if l.JSON() {
+		l.Event(logging.LevelInfo, "subsection", title, nil)
+		return
+	}
+	l.Printf(logging.LevelInfo, "\n%s>>> %s%s\n", l.Color(logging.ColorCyan), title, l.Color(logging.ColorReset))
 }
 
-func (l *Logger) Summary(title string, created, skipped, failed int) {
-	fmt.Printf("\n%s%s========== %s ==========%s\n", colorBold, colorBlue, title, colorReset)
-	fmt.Printf("%s[✓]%s Criados: %d\n", colorGreen, colorReset, created)
-	fmt.Printf("%s[⏭]%s Já existiam: %d\n", colorCyan, colorReset, skipped)
-	if failed > 0 {
-		fmt.Printf("%s[✗]%s Erros: %d\n", colorRed, colorReset, failed)
+func (l *Logger) Summary(title string, counts RunCounts, duration time.Duration) {
+	if l.JSON() {
+		l.Event(logging.LevelInfo, "summary", title, Fields{
+			"created": counts.Created, "updated": counts.Updated, "skipped": counts.Skipped, "failed": counts.Failed,
+			"warnings": counts.Warnings, "duration_ms": duration.Milliseconds(),
+		})
+		return
+	}
+
+	reset := l.Color(logging.ColorReset)
+	l.Printf(logging.LevelInfo, "\n%s========== %s ==========%s\n", l.Color(logging.ColorBold+logging.ColorBlue), title, reset)
+	l.Printf(logging.LevelInfo, "%s[✓]%s Criados: %d\n", l.Color(logging.ColorGreen), reset, counts.Created)
+	l.Printf(logging.LevelInfo, "%s[↻]%s Atualizados: %d\n", l.Color(logging.ColorBlue), reset, counts.Updated)
+	l.Printf(logging.LevelInfo, "%s[⏭]%s Já existiam: %d\n", l.Color(logging.ColorCyan), reset, counts.Skipped)
+	if counts.Failed > 0 {
+		l.Printf(logging.LevelInfo, "%s[✗]%s Erros: %d\n", l.Color(logging.ColorRed), reset, counts.Failed)
+	}
+	if counts.Warnings > 0 {
+		l.Printf(logging.LevelInfo, "%s[⚠]%s Avisos: %d\n", l.Color(logging.ColorYellow), reset, counts.Warnings)
 	}
-	fmt.Printf("%s========== Fim: %s ==========%s\n\n", colorBlue, time.Now().Format("15:04:05"), colorReset)
+	l.Printf(logging.LevelInfo, "%s[⏱]%s Tempo: %s\n", l.Color(logging.ColorBlue), reset, duration.Round(time.Millisecond))
+	l.Printf(logging.LevelInfo, "%s========== Fim: %s ==========%s\n\n", l.Color(logging.ColorBlue), time.Now().Format("15:04:05"), reset)
 }
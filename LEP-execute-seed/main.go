@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,60 +16,139 @@ import (
 func main() {
 	// ====== CARREGA CONFIGURAÇÃO ======
 	config, err := LoadConfig()
+
+	// "config print" não acessa o backend e mostra a config mesmo quando ela é inválida
+	if config != nil && len(config.Command) > 0 && config.Command[0] == "config" {
+		os.Exit(runConfigCommand(config, err))
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "[✗] Erro ao carregar config: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitValidation)
+	}
+
+	// ====== CRIAR LOGGER ======
+	logOptions, _ := config.LogOptions() // já validado por LoadConfig
+	logger, err := NewLoggerWithOptions(logOptions)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[✗] %v\n", err)
+		os.Exit(exitValidation)
+	}
+	var bundle *SeedBundle // -file com .tar.gz/.zip; removido ao sair
+	exit := func(code int) {
+		bundle.Close()
+		logger.Close()
+		os.Exit(code)
+	}
+
+	// "bundle create" só lê arquivos locais e não passa pela proteção de alvo remoto
+	if len(config.Command) > 0 && config.Command[0] == "bundle" {
+		exit(runBundleCommand(config, logger))
 	}
 
 	// ====== EXIBE CONFIGURAÇÃO ======
-	fmt.Println("\n========== 🌱 LEP Database Seeder v2.0 ==========")
-	fmt.Printf("[ℹ] URL Backend: %s\n", config.Server.URL)
-	fmt.Printf("[ℹ] Organização: %s\n", config.Auth.OrganizationName)
-	fmt.Printf("[ℹ] Log Level: %s\n", config.Logging.Level)
-	fmt.Println("================================================\n")
+	logger.Section("🌱 LEP Database Seeder v2.0")
+	logger.Info("URL Backend: %s", config.Server.URL)
+	logger.Info("Organização: %s", config.Auth.OrganizationName)
+	logger.Info("Log Level: %s", config.Logging.Level)
+	if config.Env != "" {
+		logger.Info("Ambiente: %s", config.Env)
+	}
 
-	// ====== CRIAR LOGGER ======
-	isVerbose := config.Logging.Level == "debug" || config.Logging.Level == "verbose"
-	logger := NewLogger(isVerbose)
+	// ====== PROTEÇÃO DE ALVO REMOTO ======
+	if err := guardTarget(config, logger, os.Stdin); err != nil {
+		logger.Error("%v", err)
+		exit(exitUsage)
+	}
+
+	// ====== CANCELAMENTO (Ctrl-C / SIGTERM) ======
+	// O primeiro sinal cancela as requisições em andamento e o resumo ainda é exibido e gravado;
+	// depois dele o comportamento padrão volta, então um segundo sinal encerra na hora
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	// ====== SUBCOMANDOS (migrate) ======
+	if len(config.Command) > 0 {
+		exit(runCommand(ctx, config, logger))
+	}
 
 	// ====== DETERMINAR ARQUIVOS DE SEED A EXECUTAR ======
-	seedFiles := determineSeedFiles(config.Seed.File, logger)
+	seedFiles, bundle, err := openSeedFiles(config.Seed.File, logger)
+	if err != nil {
+		logger.Error("%v", err)
+		exit(exitValidation)
+	}
 	if len(seedFiles) == 0 {
 		logger.Error("Nenhum arquivo de seed encontrado para executar")
-		os.Exit(1)
+		exit(exitValidation)
 	}
 
-	fmt.Printf("[ℹ] Arquivos de seed: %v\n\n", seedFiles)
+	logger.Info("Arquivos de seed: %v", seedFiles)
+
+	// Todos os arquivos entram no projeto do login da mesma organização: com deleções,
+	// o sync de um arquivo apagaria o que o anterior acabou de criar
+	if config.Sync.Enabled && config.Sync.Confirm && len(config.Sync.Prune) > 0 && len(seedFiles) > 1 {
+		logger.Error("-sync -yes com %d arquivos de seed no mesmo projeto (%v): rode um arquivo por vez com -file", len(seedFiles), seedFiles)
+		exit(exitUsage)
+	}
 
 	// ====== CRIAR CLIENTE DE API (COMPARTILHADO) ======
 	client := NewAPIClientV2(config.Server.URL, logger, config)
 
-	// ====== ESTADO ACUMULADO ======
-	totalCreated := 0
-	totalSkipped := 0
-	totalFailed := 0
-	allErrors := []SeedError{}
+	// ====== RELATÓRIO DA EXECUÇÃO ======
+	report := &RunReport{StartedAt: time.Now()}
 
 	// ====== EXECUTAR CADA ARQUIVO DE SEED ======
-	for _, seedFile := range seedFiles {
-		fmt.Printf("\n\n╔══════════════════════════════════════════════════════════════╗\n")
-		fmt.Printf("║ Processando: %s\n", seedFile)
-		fmt.Printf("╚══════════════════════════════════════════════════════════════╝\n\n")
+	for i, seedFile := range seedFiles {
+		if ctx.Err() != nil {
+			logger.Warn("Execução interrompida, arquivos não processados: %v", seedFiles[i:])
+			break
+		}
+		logger.Section("Processando: " + seedFile)
 
 		// ====== CARREGAR DADOS DE SEED ======
 		logger.Info(fmt.Sprintf("Carregando %s...", seedFile))
-		seedData, err := LoadSeedData(seedFile)
+		seedData, err := LoadSeedData(bundle.Resolve(seedFile))
 		if err != nil {
 			logger.Error(fmt.Sprintf("Erro ao carregar seed: %v", err))
-			totalFailed++
+			report.Files = append(report.Files, newInvalidFileReport(seedFile, "seed", err))
+			continue
+		}
+		if err := seedData.ValidateSections(); err != nil {
+			logger.Error("Seed inválido: %v", err)
+			report.Files = append(report.Files, newInvalidFileReport(seedFile, "seed", err))
+			continue
+		}
+		if err := seedData.ResolveRelativeDates(time.Now()); err != nil {
+			logger.Error("Seed inválido: %v", err)
+			report.Files = append(report.Files, newInvalidFileReport(seedFile, "seed", err))
+			continue
+		}
+		if auditSeedContrast(logger, seedData) && config.Seed.StrictContrast {
+			logger.Error("Seed abortado: contraste abaixo de WCAG AA com -strict-contrast")
+			report.Files = append(report.Files, newInvalidFileReport(seedFile, "theme_contrast", fmt.Errorf("contraste abaixo de WCAG AA com -strict-contrast")))
+			continue
+		}
+		if lintSeedLayout(logger, seedData, config.Lint.Layout, config.Lint.Strict) {
+			logger.Error("Seed abortado: erros no layout de ambientes, mesas e reservas")
+			report.Files = append(report.Files, newInvalidFileReport(seedFile, "layout", fmt.Errorf("erros no layout de ambientes, mesas e reservas")))
 			continue
 		}
 
 		totalItems := len(seedData.Menus) + len(seedData.Categories) + len(seedData.Subcategories) + len(seedData.Environments) + len(seedData.Tables) + len(seedData.Products)
+		for _, p := range seedData.Projects {
+			totalItems += len(p.Menus) + len(p.Categories) + len(p.Subcategories) + len(p.Environments) + len(p.Tables) + len(p.Products)
+		}
 		logger.Info(fmt.Sprintf("Arquivo carregado com %d items", totalItems))
+		if len(seedData.Projects) > 0 {
+			logger.Info("Projetos declarados: %d", len(seedData.Projects))
+		}
 
 		// ====== CRIAR SERVIÇO DE SEED ======
 		service := &SeedServiceV2{
+			seedFile: bundle.Resolve(seedFile),
 			client:   client,
 			logger:   logger,
 			config:   config,
@@ -80,60 +162,145 @@ func main() {
 		}
 
 		// ====== EXECUTAR SEED ======
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		// Cada passo tem seu próprio prazo (server.step_timeout) derivado de ctx
 		startTime := time.Now()
 		err = service.Execute(ctx)
 		duration := time.Since(startTime)
-		cancel()
 
 		// ====== ACUMULAR RESULTADOS ======
-		totalCreated += service.state.created
-		totalSkipped += service.state.skipped
-		totalFailed += service.state.failed
-		allErrors = append(allErrors, service.state.errors...)
+		fileReport := newFileReport(seedFile, service.state, duration)
+		switch {
+		case ctx.Err() != nil:
+			fileReport.Status = fileInterrupted
+		case err != nil:
+			// Execute só retorna erro quando a organização ou o login falham
+			fileReport.Status = fileAuthError
+		}
+		report.Files = append(report.Files, fileReport)
 
 		// ====== EXIBIR RESUMO PARCIAL ======
-		fmt.Println("\n========== 🎉 RESUMO - " + seedFile + " ==========")
-		fmt.Printf("[✓] Criados: %d\n", service.state.created)
-		fmt.Printf("[⏭] Já existiam: %d\n", service.state.skipped)
-		fmt.Printf("[✗] Erros: %d\n", service.state.failed)
-		fmt.Printf("[⏱] Tempo: %s\n", duration)
-		fmt.Println("==========================================\n")
+		logger.SetStep("")
+		logger.Summary("🎉 RESUMO - "+seedFile, fileReport.Counts, duration)
 
 		// Exibir erros deste arquivo se houver
 		if len(service.state.errors) > 0 {
-			fmt.Println("[✗] Erros detectados:")
-			for _, e := range service.state.errors {
-				fmt.Printf("  - [%s] %s: %s\n", e.Type, e.Item, e.Message)
-			}
-			fmt.Println()
+			logger.Error("Erros detectados:")
+			logSeedErrors(logger, service.state.errors)
+		}
+		if len(service.state.warnings) > 0 {
+			logger.Warn("Avisos:")
+			logSeedWarnings(logger, service.state.warnings)
 		}
 	}
 
+	report.finish()
+	if ctx.Err() != nil {
+		logger.Warn("Execução interrompida (Ctrl-C/SIGTERM), o resumo abaixo é parcial")
+	}
+
 	// ====== EXIBIR RESUMO TOTAL ======
-	fmt.Println("\n╔══════════════════════════════════════════════════════════════╗")
-	fmt.Println("║               RESUMO TOTAL DA EXECUÇÃO                        ║")
-	fmt.Println("╚══════════════════════════════════════════════════════════════╝\n")
-	fmt.Printf("[✓] Total Criados: %d\n", totalCreated)
-	fmt.Printf("[⏭] Total Já Existiam: %d\n", totalSkipped)
-	fmt.Printf("[✗] Total Erros: %d\n", totalFailed)
-	fmt.Println()
+	logger.Summary("RESUMO TOTAL DA EXECUÇÃO", report.Totals, time.Duration(report.DurationMs)*time.Millisecond)
 
 	// ====== EXIBIR TODOS OS ERROS SE HOUVER ======
-	if len(allErrors) > 0 {
-		fmt.Println("[✗] Erros detectados no total:")
-		for _, e := range allErrors {
-			fmt.Printf("  - [%s] %s: %s\n", e.Type, e.Item, e.Message)
+	if report.Totals.Failed > 0 {
+		logger.Error("Erros detectados no total:")
+		for _, f := range report.Files {
+			logSeedErrors(logger, f.Errors)
+		}
+	}
+
+	// ====== RELATÓRIO JSON (-report) ======
+	if config.Report != "" {
+		if err := report.Write(config.Report); err != nil {
+			logger.Error("%v", err)
+		} else {
+			logger.Info("Relatório gravado em %s", config.Report)
 		}
-		fmt.Println()
 	}
 
 	// ====== SAIR COM STATUS CORRETO ======
-	if totalFailed > 0 {
-		os.Exit(1)
+	exit(report.ExitCode)
+}
+
+// logSeedErrors lista os erros, com tipo, status HTTP e passo como campos no log JSON
+func logSeedErrors(logger *Logger, errs []SeedError) {
+	for _, e := range errs {
+		logger.With(seedErrorFields(e)).Error("  - [%s] %s: %s", e.Type, e.Item, e.Message)
+	}
+}
+
+func logSeedWarnings(logger *Logger, warnings []SeedError) {
+	for _, e := range warnings {
+		logger.With(seedErrorFields(e)).Warn("  - [%s] %s: %s", e.Type, e.Item, e.Message)
+	}
+}
+
+func seedErrorFields(e SeedError) Fields {
+	fields := Fields{"entity": e.Type}
+	if e.Status != 0 {
+		fields["status"] = e.Status
+	}
+	if e.Step != "" {
+		fields["step"] = e.Step
+	}
+	return fields
+}
+
+// runCommand executa um subcomando e retorna o código de saída
+func runCommand(ctx context.Context, config *Config, logger *Logger) int {
+	switch config.Command[0] {
+	case "migrate":
+		action := "up"
+		if len(config.Command) > 1 {
+			action = config.Command[1]
+		}
+
+		// Projetos declarados nos arquivos de seed também recebem as migrations
+		seedFiles, bundle, err := openSeedFiles(config.Seed.File, logger)
+		if err != nil {
+			logger.Error("%v", err)
+			return exitValidation
+		}
+		defer bundle.Close()
+
+		var projects []string
+		for _, seedFile := range seedFiles {
+			seedData, err := LoadSeedData(bundle.Resolve(seedFile))
+			if err != nil {
+				logger.Error("Erro ao carregar %s: %v", seedFile, err)
+				return exitValidation
+			}
+			for _, p := range seedData.Projects {
+				projects = append(projects, p.Name)
+			}
+		}
+
+		service := &SeedServiceV2{
+			client: NewAPIClientV2(config.Server.URL, logger, config),
+			logger: logger,
+			config: config,
+			state:  &SeedState{errors: []SeedError{}},
+		}
+		return service.runMigrations(ctx, action, projects)
+	}
+
+	logger.Error("Comando desconhecido: %s", config.Command[0])
+	return exitUsage
+}
+
+// runConfigCommand executa "config print"; loadErr é o erro de validação de LoadConfig, se houver
+func runConfigCommand(config *Config, loadErr error) int {
+	if len(config.Command) < 2 || config.Command[1] != "print" {
+		fmt.Fprintf(os.Stderr, "[✗] Comando desconhecido: %s (use config print)\n", strings.Join(config.Command, " "))
+		return exitUsage
 	}
 
-	os.Exit(0)
+	config.Print(os.Stdout)
+	if loadErr != nil {
+		fmt.Fprintf(os.Stderr, "\n[✗] Config inválida:\n%v\n", loadErr)
+		return exitValidation
+	}
+	return exitOK
 }
 
 // determineSeedFiles retorna lista de arquivos de seed a executar
@@ -141,7 +308,7 @@ func main() {
 // Caso contrário, executa ambos os arquivos padrão: seed-fattoria.json e seed-data.json
 func determineSeedFiles(configFile string, logger *Logger) []string {
 	// Se foi passado -file na CLI, retorna apenas esse arquivo
-	if configFile != "seed-fattoria.json" {
+	if configFile != defaultSeedFile {
 		if _, err := os.Stat(configFile); err == nil {
 			return []string{configFile}
 		}
@@ -166,6 +333,7 @@ func determineSeedFiles(configFile string, logger *Logger) []string {
 
 // SeedServiceV2 gerencia a execução do seed contra o backend
 type SeedServiceV2 struct {
+	seedFile string
 	client   *APIClientV2
 	logger   *Logger
 	config   *Config
@@ -175,32 +343,41 @@ type SeedServiceV2 struct {
 
 // SeedState rastreia o estado da execução
 type SeedState struct {
-	created int
-	skipped int
-	failed  int
-	errors  []SeedError
+	created  int
+	skipped  int
+	updated  int
+	failed   int
+	errors   []SeedError
+	warnings []SeedError // avisos (ex.: duplicatas no backend), não contam como falha
+
+	project   string        // projeto em execução ("" = projeto do login)
+	steps     []*StepReport // passos executados, para o relatório
+	resolved  map[string]map[string]map[string]string
+	cancelled bool // Ctrl-C/SIGTERM já registrado como erro
+	ids       *idLedger
+	idsFailed bool // ledger de IDs ilegível: casamento só pela chave natural
 }
 
 // SeedError representa um erro durante execução
 type SeedError struct {
-	Type    string // auth, menu, category, etc
-	Item    string // nome do item
-	Message string // mensagem de erro
+	Type    string `json:"type"`                    // auth, menu, category, etc
+	Item    string `json:"item"`                    // nome do item
+	Message string `json:"message"`                 // mensagem de erro
+	Project string `json:"project,omitempty"`       // projeto em que o erro ocorreu
+	Step    string `json:"step,omitempty"`          // passo em que o erro ocorreu
+	Status  int    `json:"http_status,omitempty"`   // status HTTP da resposta de erro
+	Body    string `json:"response_body,omitempty"` // corpo da resposta de erro
 }
 
 // Execute executa o seed completo
-func (s *SeedServiceV2) Execute(ctx context.Context) error {
+func (s *SeedServiceV2) Execute(runCtx context.Context) error {
 	// PASSO 1: Criar/Obter Organização e Fazer Login
-	fmt.Println("========== Passo 1: Criando Organização ==========")
-	orgID, projID, email, err := s.createOrganization()
+	ctx := s.beginStep(runCtx, "Passo 1: Criando Organização")
+	orgID, projID, email, err := s.createOrganization(ctx)
 	if err != nil {
 		s.logger.Error(fmt.Sprintf("Erro ao criar organização: %v", err))
 		s.state.failed++
-		s.state.errors = append(s.state.errors, SeedError{
-			Type:    "org",
-			Item:    s.config.Auth.OrganizationName,
-			Message: err.Error(),
-		})
+		s.state.errors = append(s.state.errors, newSeedError("org", s.config.Auth.OrganizationName, err))
 		return err
 	}
 
@@ -208,56 +385,76 @@ func (s *SeedServiceV2) Execute(ctx context.Context) error {
 	s.state.created++
 
 	// PASSO 2: Fazer Login
-	fmt.Println("\n========== Passo 2: Fazendo Login ==========")
-	err = s.login(email)
+	ctx = s.beginStep(runCtx, "Passo 2: Fazendo Login")
+	err = s.login(ctx, email)
 	if err != nil {
 		s.logger.Error(fmt.Sprintf("Erro ao fazer login: %v", err))
 		s.state.failed++
-		s.state.errors = append(s.state.errors, SeedError{
-			Type:    "auth",
-			Item:    email,
-			Message: err.Error(),
-		})
+		s.state.errors = append(s.state.errors, newSeedError("auth", email, err))
 		return err
 	}
 
 	s.logger.Info(fmt.Sprintf("Autenticado como %s", email))
 	s.client.SetHeaders(s.client.token, orgID, projID)
 
+	// PASSO 2b: Perfil da organização
+	ctx = s.beginStep(runCtx, "Passo 2b: Atualizando Perfil da Organização")
+	s.applyOrganizationProfile(ctx, orgID)
+
+	// Seções de nível superior vão para o projeto retornado no login
+	s.seedSections(runCtx)
+
+	// Projetos declarados em "projects", cada um com suas próprias seções
+	s.seedProjects(runCtx, orgID, projID)
+
+	s.saveIDLedger()
+
+	return nil
+}
+
+// seedSections executa os passos 3 a 18 com as seções de s.seedData no projeto atual do client
+func (s *SeedServiceV2) seedSections(runCtx context.Context) {
 	// PASSO 3: Criar Menus
-	fmt.Println("\n========== Passo 3: Criando Menus ==========")
+	ctx := s.beginStep(runCtx, "Passo 3: Criando Menus")
 	menuIDs := make(map[int]string) // idx -> UUID
 	for idx, menu := range s.seedData.Menus {
+		if s.interrupted(ctx) {
+			break
+		}
+
 		// Verificar se menu já existe
-		existingID, err := s.client.GetMenuByName(menu.Name)
-		if err == nil && existingID != uuid.Nil {
-			menuIDs[idx] = existingID.String()
-			s.logger.Info(fmt.Sprintf("Menu %s já existe", menu.Name))
-			s.state.skipped++
+		ref := seedEntity{kind: "menu", path: "/menu", keyField: "name", key: menu.Name, externalID: menu.ExternalID}
+		if match := s.matchEntity(ctx, ref); match.id != uuid.Nil {
+			menuIDs[idx] = match.id.String()
+			if !match.renamed {
+				s.logger.Info("Menu %s já existe", menu.Name)
+				s.state.skipped++
+			}
 			continue
 		}
 
 		// Criar novo menu
-		id, err := s.client.CreateMenu(menu.Name, menu.Order)
+		id, err := s.client.CreateMenu(ctx, menu.Name, menu.Order)
 		if err != nil {
 			s.logger.Error(fmt.Sprintf("Erro ao criar menu %s: %v", menu.Name, err))
 			s.state.failed++
-			s.state.errors = append(s.state.errors, SeedError{
-				Type:    "menu",
-				Item:    menu.Name,
-				Message: err.Error(),
-			})
+			s.state.errors = append(s.state.errors, newSeedError("menu", menu.Name, err))
 		} else {
 			menuIDs[idx] = id.String()
+			s.rememberExternalID(ref, id)
 			s.logger.Info(fmt.Sprintf("Menu criado: %s", menu.Name))
 			s.state.created++
 		}
 	}
 
 	// PASSO 4: Criar Categorias
-	fmt.Println("\n========== Passo 4: Criando Categorias ==========")
+	ctx = s.beginStep(runCtx, "Passo 4: Criando Categorias")
 	categoryIDs := make(map[int]string) // idx -> UUID
 	for idx, cat := range s.seedData.Categories {
+		if s.interrupted(ctx) {
+			break
+		}
+
 		menuID, ok := menuIDs[cat.MenuIDRef]
 		if !ok {
 			s.logger.Error(fmt.Sprintf("Menu não encontrado para categoria %s", cat.Name))
@@ -266,120 +463,129 @@ func (s *SeedServiceV2) Execute(ctx context.Context) error {
 		}
 
 		// Verificar se categoria já existe
-		existingID, err := s.client.GetCategoryByName(cat.Name)
-		if err == nil && existingID != uuid.Nil {
-			categoryIDs[idx] = existingID.String()
-			s.logger.Info(fmt.Sprintf("Categoria %s já existe", cat.Name))
-			s.state.skipped++
+		ref := seedEntity{kind: "category", path: "/category", keyField: "name", key: cat.Name, externalID: cat.ExternalID}
+		if match := s.matchEntity(ctx, ref); match.id != uuid.Nil {
+			categoryIDs[idx] = match.id.String()
+			if !match.renamed {
+				s.logger.Info("Categoria %s já existe", cat.Name)
+				s.state.skipped++
+			}
 			continue
 		}
 
 		// Se não existe, criar nova
-		id, err := s.client.CreateCategory(menuID, cat.Name, cat.Order)
+		id, err := s.client.CreateCategory(ctx, menuID, cat.Name, cat.Order)
 		if err != nil {
 			s.logger.Error(fmt.Sprintf("Erro ao criar categoria %s: %v", cat.Name, err))
 			s.state.failed++
-			s.state.errors = append(s.state.errors, SeedError{
-				Type:    "category",
-				Item:    cat.Name,
-				Message: err.Error(),
-			})
+			s.state.errors = append(s.state.errors, newSeedError("category", cat.Name, err))
 		} else {
 			categoryIDs[idx] = id.String()
+			s.rememberExternalID(ref, id)
 			s.logger.Info(fmt.Sprintf("Categoria criada: %s", cat.Name))
 			s.state.created++
 		}
 	}
 
 	// PASSO 5: Criar Subcategorias
-	fmt.Println("\n========== Passo 5: Criando Subcategorias ==========")
+	ctx = s.beginStep(runCtx, "Passo 5: Criando Subcategorias")
 	subcategoryIDs := make(map[int]string) // idx -> UUID
 	for idx, subcat := range s.seedData.Subcategories {
-		catID, ok := categoryIDs[subcat.CategoryIDRef]
-		if !ok {
-			s.logger.Error(fmt.Sprintf("Categoria não encontrada para subcategoria %s", subcat.Name))
-			s.state.failed++
+		if s.interrupted(ctx) {
+			break
+		}
+
+		var catIDs []string
+		for _, ref := range subcat.CategoryRefs() {
+			catID, ok := categoryIDs[ref]
+			if !ok {
+				s.logger.Error("Categoria %d não encontrada para subcategoria %s", ref, subcat.Name)
+				s.state.failed++
+				continue
+			}
+			catIDs = append(catIDs, catID)
+		}
+		if len(catIDs) == 0 {
 			continue
 		}
 
 		// Verificar se subcategoria já existe
-		existingID, err := s.client.GetSubcategoryByName(subcat.Name)
-		if err == nil && existingID != uuid.Nil {
-			subcategoryIDs[idx] = existingID.String()
-			s.logger.Info(fmt.Sprintf("Subcategoria %s já existe", subcat.Name))
-			s.state.skipped++
-			// Ainda precisamos vincular à categoria
-			err = s.client.AddCategoryToSubcategory(existingID.String(), catID)
-			if err != nil {
-				s.logger.Debug(fmt.Sprintf("Relação subcategoria-categoria já existe ou erro: %v", err))
+		ref := seedEntity{kind: "subcategory", path: "/subcategory", keyField: "name", key: subcat.Name, externalID: subcat.ExternalID}
+		if match := s.matchEntity(ctx, ref); match.id != uuid.Nil {
+			subcategoryIDs[idx] = match.id.String()
+			if !match.renamed {
+				s.logger.Info("Subcategoria %s já existe", subcat.Name)
+				s.state.skipped++
 			}
+			// Ainda precisamos conferir os vínculos com as categorias
+			s.reconcileSubcategoryLinks(ctx, subcat.Name, match.id.String(), catIDs)
 			continue
 		}
 
-		id, err := s.client.CreateSubcategory(catID, subcat.Name)
+		id, err := s.client.CreateSubcategory(ctx, catIDs[0], subcat.Name)
 		if err != nil {
 			s.logger.Error(fmt.Sprintf("Erro ao criar subcategoria %s: %v", subcat.Name, err))
 			s.state.failed++
-			s.state.errors = append(s.state.errors, SeedError{
-				Type:    "subcategory",
-				Item:    subcat.Name,
-				Message: err.Error(),
-			})
+			s.state.errors = append(s.state.errors, newSeedError("subcategory", subcat.Name, err))
 		} else {
 			subcategoryIDs[idx] = id.String()
+			s.rememberExternalID(ref, id)
 			s.logger.Info(fmt.Sprintf("Subcategoria criada: %s", subcat.Name))
 			s.state.created++
 
-			// Vincular subcategoria à categoria (relacionamento N:M)
-			err = s.client.AddCategoryToSubcategory(id.String(), catID)
-			if err != nil {
-				s.logger.Error(fmt.Sprintf("Erro ao vincular subcategoria %s à categoria: %v", subcat.Name, err))
-				s.state.failed++
-			} else {
-				s.logger.Info(fmt.Sprintf("Subcategoria %s vinculada à categoria", subcat.Name))
-			}
+			// Vincular subcategoria às categorias (relacionamento N:M)
+			s.reconcileSubcategoryLinks(ctx, subcat.Name, id.String(), catIDs)
 		}
 	}
 
 	// PASSO 6: Criar Ambientes
-	fmt.Println("\n========== Passo 6: Criando Ambientes ==========")
+	ctx = s.beginStep(runCtx, "Passo 6: Criando Ambientes")
 	envIDs := make(map[int]string) // idx -> UUID
 	for idx, env := range s.seedData.Environments {
+		if s.interrupted(ctx) {
+			break
+		}
+
 		// Verificar se ambiente já existe
-		existingID, err := s.client.GetEnvironmentByName(env.Name)
-		if err == nil && existingID != uuid.Nil {
-			envIDs[idx] = existingID.String()
-			s.logger.Info(fmt.Sprintf("Ambiente %s já existe", env.Name))
-			s.state.skipped++
+		ref := seedEntity{kind: "environment", path: "/environment", keyField: "name", key: env.Name, externalID: env.ExternalID}
+		if match := s.matchEntity(ctx, ref); match.id != uuid.Nil {
+			envIDs[idx] = match.id.String()
+			if !match.renamed {
+				s.logger.Info("Ambiente %s já existe", env.Name)
+				s.state.skipped++
+			}
 			continue
 		}
 
-		id, err := s.client.CreateEnvironment(env.Name, env.Capacity)
+		id, err := s.client.CreateEnvironment(ctx, env.Name, env.Capacity)
 		if err != nil {
 			s.logger.Error(fmt.Sprintf("Erro ao criar ambiente %s: %v", env.Name, err))
 			s.state.failed++
-			s.state.errors = append(s.state.errors, SeedError{
-				Type:    "environment",
-				Item:    env.Name,
-				Message: err.Error(),
-			})
+			s.state.errors = append(s.state.errors, newSeedError("environment", env.Name, err))
 		} else {
 			envIDs[idx] = id.String()
+			s.rememberExternalID(ref, id)
 			s.logger.Info(fmt.Sprintf("Ambiente criado: %s", env.Name))
 			s.state.created++
 		}
 	}
 
 	// PASSO 7: Criar Mesas
-	fmt.Println("\n========== Passo 7: Criando Mesas ==========")
+	ctx = s.beginStep(runCtx, "Passo 7: Criando Mesas")
 	tableIDs := make(map[int]string) // idx -> UUID
 	for idx, tbl := range s.seedData.Tables {
+		if s.interrupted(ctx) {
+			break
+		}
+
 		// Verificar se mesa já existe
-		existingID, err := s.client.GetTableByNumber(tbl.Number)
-		if err == nil && existingID != uuid.Nil {
-			tableIDs[idx] = existingID.String()
-			s.logger.Info(fmt.Sprintf("Mesa %d já existe", tbl.Number))
-			s.state.skipped++
+		ref := seedEntity{kind: "table", path: "/table", keyField: "number", key: tbl.Number, externalID: tbl.ExternalID}
+		if match := s.matchEntity(ctx, ref); match.id != uuid.Nil {
+			tableIDs[idx] = match.id.String()
+			if !match.renamed {
+				s.logger.Info("Mesa %d já existe", tbl.Number)
+				s.state.skipped++
+			}
 			continue
 		}
 
@@ -390,192 +596,138 @@ func (s *SeedServiceV2) Execute(ctx context.Context) error {
 			}
 		}
 
-		id, err := s.client.CreateTable(tbl.Number, tbl.Capacity, envID, "livre")
+		status := tbl.Status
+		if status == "" {
+			status = "livre"
+		}
+
+		id, err := s.client.CreateTable(ctx, tbl.Number, tbl.Capacity, envID, status)
 		if err != nil {
 			s.logger.Error(fmt.Sprintf("Erro ao criar mesa %d: %v", tbl.Number, err))
 			s.state.failed++
-			s.state.errors = append(s.state.errors, SeedError{
-				Type:    "table",
-				Item:    fmt.Sprintf("mesa_%d", tbl.Number),
-				Message: err.Error(),
-			})
+			s.state.errors = append(s.state.errors, newSeedError("table", fmt.Sprintf("mesa_%d", tbl.Number), err))
 		} else {
 			tableIDs[idx] = id.String()
+			s.rememberExternalID(ref, id)
 			s.logger.Info(fmt.Sprintf("Mesa criada: %d", tbl.Number))
 			s.state.created++
 		}
 	}
 
 	// PASSO 8: Criar Produtos
-	fmt.Println("\n========== Passo 8: Criando Produtos ==========")
-	productIDs := make(map[int]string) // idx -> UUID (para ProductTags)
-	for idx, prod := range s.seedData.Products {
-		// Verificar se produto já existe
-		existingID, err := s.client.GetProductByName(prod.Name)
-		if err == nil && existingID != uuid.Nil {
-			productIDs[idx] = existingID.String()
-			s.logger.Info(fmt.Sprintf("Produto %s já existe", prod.Name))
-			s.state.skipped++
-			continue
-		}
-
-		var menuID, catID, subcatID *string
-
-		// Obter menu_id
-		if prod.MenuIDRef >= 0 && prod.MenuIDRef < len(s.seedData.Menus) {
-			if id, ok := menuIDs[prod.MenuIDRef]; ok {
-				menuID = &id
-			}
-		}
-
-		if prod.CategoryIDRef >= 0 && prod.CategoryIDRef < len(s.seedData.Categories) {
-			if id, ok := categoryIDs[prod.CategoryIDRef]; ok {
-				catID = &id
-			}
-		}
+	ctx = s.beginStep(runCtx, "Passo 8: Criando Produtos")
+	productIDs := s.seedProducts(ctx, menuIDs, categoryIDs, subcategoryIDs) // idx -> UUID (para ProductTags)
 
-		if prod.SubcategoryIDRef >= 0 && prod.SubcategoryIDRef < len(s.seedData.Subcategories) {
-			if id, ok := subcategoryIDs[prod.SubcategoryIDRef]; ok {
-				subcatID = &id
-			}
-		}
-
-		// Preparar dados de vinho se aplicável
-		var wineData *WineData
-		if prod.Type == "vinho" && (prod.Vintage != "" || prod.Country != "" || prod.Region != "") {
-			wineData = &WineData{
-				Vintage:        prod.Vintage,
-				Country:        prod.Country,
-				Region:         prod.Region,
-				Winery:         prod.Winery,
-				WineType:       prod.WineType,
-				Volume:         prod.Volume,
-				AlcoholContent: prod.AlcoholContent,
-				PriceBottle:    prod.PriceBottle,
-				PriceGlass:     prod.PriceGlass,
-			}
-		}
-
-		id, err := s.client.CreateProduct(
-			prod.Name,
-			prod.Type,
-			prod.PriceNormal,
-			prod.PrepTimeMinutes,
-			menuID,
-			catID,
-			subcatID,
-			wineData,
-		)
-
-		if err != nil {
-			s.logger.Error(fmt.Sprintf("Erro ao criar produto %s: %v", prod.Name, err))
-			s.state.failed++
-			s.state.errors = append(s.state.errors, SeedError{
-				Type:    "product",
-				Item:    prod.Name,
-				Message: err.Error(),
-			})
-		} else {
-			productIDs[idx] = id.String()
-			if wineData != nil {
-				s.logger.Info(fmt.Sprintf("Produto criado: %s (%s) - %s %s", prod.Name, prod.Type, prod.Country, prod.Vintage))
-			} else {
-				s.logger.Info(fmt.Sprintf("Produto criado: %s (%s)", prod.Name, prod.Type))
-			}
-			s.state.created++
-		}
-	}
+	// PASSO 8b: Enviar Imagens
+	ctx = s.beginStep(runCtx, "Passo 8b: Enviando Imagens")
+	s.seedImages(ctx, categoryIDs, productIDs)
 
 	// PASSO 9: Criar Usuários
-	fmt.Println("\n========== Passo 9: Criando Usuários ==========")
+	ctx = s.beginStep(runCtx, "Passo 9: Criando Usuários")
 	userIDs := make(map[int]string) // idx -> UUID
 	for idx, user := range s.seedData.Users {
+		if s.interrupted(ctx) {
+			break
+		}
+
 		// Verificar se usuário já existe
-		existingID, err := s.client.GetUserByEmail(user.Email)
-		if err == nil && existingID != uuid.Nil {
-			userIDs[idx] = existingID.String()
-			s.logger.Info(fmt.Sprintf("Usuário %s já existe", user.Email))
-			s.state.skipped++
+		ref := seedEntity{kind: "user", path: "/user", keyField: "email", key: user.Email, externalID: user.ExternalID}
+		if match := s.matchEntity(ctx, ref); match.id != uuid.Nil {
+			userIDs[idx] = match.id.String()
+			if !match.renamed {
+				s.logger.Info("Usuário %s já existe", user.Email)
+				s.state.skipped++
+			}
+			s.reconcileMemberships(ctx, user, match.id.String())
 			continue
 		}
 
-		id, err := s.client.CreateUser(user.Name, user.Email, user.Password, user.Role, user.Permissions)
+		id, err := s.client.CreateUser(ctx, user.Name, user.Email, user.Password, user.Role, user.Permissions)
 		if err != nil {
 			s.logger.Error(fmt.Sprintf("Erro ao criar usuário %s: %v", user.Email, err))
 			s.state.failed++
-			s.state.errors = append(s.state.errors, SeedError{
-				Type:    "user",
-				Item:    user.Email,
-				Message: err.Error(),
-			})
+			s.state.errors = append(s.state.errors, newSeedError("user", user.Email, err))
 		} else {
 			userIDs[idx] = id.String()
+			s.rememberExternalID(ref, id)
 			s.logger.Info(fmt.Sprintf("Usuário criado: %s (%s)", user.Email, user.Role))
 			s.state.created++
+			s.reconcileMemberships(ctx, user, id.String())
 		}
 	}
 
 	// PASSO 10: Criar Clientes
-	fmt.Println("\n========== Passo 10: Criando Clientes ==========")
+	ctx = s.beginStep(runCtx, "Passo 10: Criando Clientes")
 	customerIDs := make(map[int]string) // idx -> UUID
 	for idx, cust := range s.seedData.Customers {
+		if s.interrupted(ctx) {
+			break
+		}
+
 		// Verificar se cliente já existe
-		existingID, err := s.client.GetCustomerByEmail(cust.Email)
-		if err == nil && existingID != uuid.Nil {
-			customerIDs[idx] = existingID.String()
-			s.logger.Info(fmt.Sprintf("Cliente %s já existe", cust.Email))
-			s.state.skipped++
+		ref := seedEntity{kind: "customer", path: "/customer", keyField: "email", key: cust.Email, externalID: cust.ExternalID}
+		if match := s.matchEntity(ctx, ref); match.id != uuid.Nil {
+			customerIDs[idx] = match.id.String()
+			if !match.renamed {
+				s.logger.Info("Cliente %s já existe", cust.Email)
+				s.state.skipped++
+			}
 			continue
 		}
 
-		id, err := s.client.CreateCustomer(cust.Name, cust.Email, cust.Phone, cust.BirthDate, cust.Notes)
+		id, err := s.client.CreateCustomer(ctx, cust.Name, cust.Email, cust.Phone, cust.BirthDate, cust.Notes)
 		if err != nil {
 			s.logger.Error(fmt.Sprintf("Erro ao criar cliente %s: %v", cust.Email, err))
 			s.state.failed++
-			s.state.errors = append(s.state.errors, SeedError{
-				Type:    "customer",
-				Item:    cust.Email,
-				Message: err.Error(),
-			})
+			s.state.errors = append(s.state.errors, newSeedError("customer", cust.Email, err))
 		} else {
 			customerIDs[idx] = id.String()
+			s.rememberExternalID(ref, id)
 			s.logger.Info(fmt.Sprintf("Cliente criado: %s", cust.Email))
 			s.state.created++
 		}
 	}
 
 	// PASSO 11: Criar Tags
-	fmt.Println("\n========== Passo 11: Criando Tags ==========")
+	ctx = s.beginStep(runCtx, "Passo 11: Criando Tags")
 	tagIDs := make(map[int]string) // idx -> UUID
 	for idx, tag := range s.seedData.Tags {
+		if s.interrupted(ctx) {
+			break
+		}
+
 		// Verificar se tag já existe
-		existingID, err := s.client.GetTagByName(tag.Name)
-		if err == nil && existingID != uuid.Nil {
-			tagIDs[idx] = existingID.String()
-			s.logger.Info(fmt.Sprintf("Tag %s já existe", tag.Name))
-			s.state.skipped++
+		ref := seedEntity{kind: "tag", path: "/tag", keyField: "name", key: tag.Name, externalID: tag.ExternalID}
+		if match := s.matchEntity(ctx, ref); match.id != uuid.Nil {
+			tagIDs[idx] = match.id.String()
+			if !match.renamed {
+				s.logger.Info("Tag %s já existe", tag.Name)
+				s.state.skipped++
+			}
 			continue
 		}
 
-		id, err := s.client.CreateTag(tag.Name, tag.Color, tag.Description, tag.EntityType)
+		id, err := s.client.CreateTag(ctx, tag.Name, tag.Color, tag.Description, tag.EntityType)
 		if err != nil {
 			s.logger.Error(fmt.Sprintf("Erro ao criar tag %s: %v", tag.Name, err))
 			s.state.failed++
-			s.state.errors = append(s.state.errors, SeedError{
-				Type:    "tag",
-				Item:    tag.Name,
-				Message: err.Error(),
-			})
+			s.state.errors = append(s.state.errors, newSeedError("tag", tag.Name, err))
 		} else {
 			tagIDs[idx] = id.String()
+			s.rememberExternalID(ref, id)
 			s.logger.Info(fmt.Sprintf("Tag criada: %s", tag.Name))
 			s.state.created++
 		}
 	}
 
 	// PASSO 12: Criar Reservas
-	fmt.Println("\n========== Passo 12: Criando Reservas ==========")
-	for _, res := range s.seedData.Reservations {
+	ctx = s.beginStep(runCtx, "Passo 12: Criando Reservas")
+	reservationIDs := make(map[int]string) // idx -> UUID
+	for idx, res := range s.seedData.Reservations {
+		if s.interrupted(ctx) {
+			break
+		}
+
 		// Obter IDs dos clientes e mesas
 		custID, ok := customerIDs[res.CustomerIDRef]
 		if !ok {
@@ -592,14 +744,18 @@ func (s *SeedServiceV2) Execute(ctx context.Context) error {
 		}
 
 		// Verificar se reserva já existe (pela confirmation_key)
-		existingID, err := s.client.GetReservationByConfirmationKey(res.ConfirmationKey)
-		if err == nil && existingID != uuid.Nil {
-			s.logger.Info(fmt.Sprintf("Reserva %s já existe", res.ConfirmationKey))
-			s.state.skipped++
+		ref := seedEntity{kind: "reservation", path: "/reservation", keyField: "confirmation_key", key: res.ConfirmationKey, externalID: res.ExternalID}
+		if match := s.matchEntity(ctx, ref); match.id != uuid.Nil {
+			reservationIDs[idx] = match.id.String()
+			if !match.renamed {
+				s.logger.Info("Reserva %s já existe", res.ConfirmationKey)
+				s.state.skipped++
+			}
 			continue
 		}
 
-		_, err = s.client.CreateReservation(
+		id, err := s.client.CreateReservation(
+			ctx,
 			custID,
 			tblID,
 			res.DateTime,
@@ -612,21 +768,23 @@ func (s *SeedServiceV2) Execute(ctx context.Context) error {
 		if err != nil {
 			s.logger.Error(fmt.Sprintf("Erro ao criar reserva %s: %v", res.ConfirmationKey, err))
 			s.state.failed++
-			s.state.errors = append(s.state.errors, SeedError{
-				Type:    "reservation",
-				Item:    res.ConfirmationKey,
-				Message: err.Error(),
-			})
+			s.state.errors = append(s.state.errors, newSeedError("reservation", res.ConfirmationKey, err))
 		} else {
+			reservationIDs[idx] = id.String()
+			s.rememberExternalID(ref, id)
 			s.logger.Info(fmt.Sprintf("Reserva criada: %s (%d pessoas)", res.ConfirmationKey, res.PartySize))
 			s.state.created++
 		}
 	}
 
 	// PASSO 13: Criar Product Tags (relacionamento N:M)
-	fmt.Println("\n========== Passo 13: Criando Product Tags ==========")
+	ctx = s.beginStep(runCtx, "Passo 13: Criando Product Tags")
 	if len(s.seedData.ProductTags) > 0 {
 		for _, pt := range s.seedData.ProductTags {
+			if s.interrupted(ctx) {
+				break
+			}
+
 			prodID, ok := productIDs[pt.ProductIDRef]
 			if !ok {
 				s.logger.Error(fmt.Sprintf("Produto não encontrado para tag"))
@@ -641,7 +799,7 @@ func (s *SeedServiceV2) Execute(ctx context.Context) error {
 				continue
 			}
 
-			err := s.client.AddTagToProduct(prodID, tagID)
+			err := s.client.AddTagToProduct(ctx, prodID, tagID)
 			if err != nil {
 				s.logger.Error(fmt.Sprintf("Erro ao vincular tag ao produto: %v", err))
 				s.state.failed++
@@ -654,18 +812,29 @@ func (s *SeedServiceV2) Execute(ctx context.Context) error {
 		s.logger.Info("Nenhum ProductTag definido no seed")
 	}
 
+	// PASSO 13b: Tags em menus, clientes, mesas e reservas
+	ctx = s.beginStep(runCtx, "Passo 13b: Vinculando Tags a Outras Entidades")
+	s.seedTagAssignments(ctx, tagIDs, map[string]map[int]string{
+		"product":     productIDs,
+		"menu":        menuIDs,
+		"customer":    customerIDs,
+		"table":       tableIDs,
+		"reservation": reservationIDs,
+	})
+
+	// Com Ctrl-C/SIGTERM os passos de configuração não são aplicados pela metade
+	if s.interrupted(runCtx) {
+		return
+	}
+
 	// PASSO 14: Criar Settings
-	fmt.Println("\n========== Passo 14: Criando Settings ==========")
+	ctx = s.beginStep(runCtx, "Passo 14: Criando Settings")
 	if s.seedData.Settings.Timezone != "" || s.seedData.Settings.ReservationMinAdvanceHours > 0 {
-		err := s.client.CreateSettings(&s.seedData.Settings)
+		err := s.client.CreateSettings(ctx, &s.seedData.Settings)
 		if err != nil {
 			s.logger.Error(fmt.Sprintf("Erro ao criar settings: %v", err))
 			s.state.failed++
-			s.state.errors = append(s.state.errors, SeedError{
-				Type:    "settings",
-				Item:    "project_settings",
-				Message: err.Error(),
-			})
+			s.state.errors = append(s.state.errors, newSeedError("settings", "project_settings", err))
 		} else {
 			s.logger.Info("Settings criado/atualizado com sucesso")
 			s.state.created++
@@ -675,27 +844,30 @@ func (s *SeedServiceV2) Execute(ctx context.Context) error {
 	}
 
 	// PASSO 15: Criar Notification Templates
-	fmt.Println("\n========== Passo 15: Criando Notification Templates ==========")
+	ctx = s.beginStep(runCtx, "Passo 15: Criando Notification Templates")
 	if len(s.seedData.NotificationTemplates) > 0 {
 		for _, tmpl := range s.seedData.NotificationTemplates {
+			if s.interrupted(ctx) {
+				break
+			}
+
 			// Verificar se template já existe
-			existingID, err := s.client.GetNotificationTemplateByName(tmpl.Name)
-			if err == nil && existingID != uuid.Nil {
-				s.logger.Info(fmt.Sprintf("Template %s já existe", tmpl.Name))
-				s.state.skipped++
+			ref := seedEntity{kind: "notification_template", path: "/notification-template", keyField: "name", key: tmpl.Name, externalID: tmpl.ExternalID}
+			if match := s.matchEntity(ctx, ref); match.id != uuid.Nil {
+				if !match.renamed {
+					s.logger.Info("Template %s já existe", tmpl.Name)
+					s.state.skipped++
+				}
 				continue
 			}
 
-			_, err = s.client.CreateNotificationTemplate(&tmpl)
+			id, err := s.client.CreateNotificationTemplate(ctx, &tmpl)
 			if err != nil {
 				s.logger.Error(fmt.Sprintf("Erro ao criar template %s: %v", tmpl.Name, err))
 				s.state.failed++
-				s.state.errors = append(s.state.errors, SeedError{
-					Type:    "notification_template",
-					Item:    tmpl.Name,
-					Message: err.Error(),
-				})
+				s.state.errors = append(s.state.errors, newSeedError("notification_template", tmpl.Name, err))
 			} else {
+				s.rememberExternalID(ref, id)
 				s.logger.Info(fmt.Sprintf("Template criado: %s (%s)", tmpl.Name, tmpl.Channel))
 				s.state.created++
 			}
@@ -705,17 +877,13 @@ func (s *SeedServiceV2) Execute(ctx context.Context) error {
 	}
 
 	// PASSO 16: Criar Theme Customization
-	fmt.Println("\n========== Passo 16: Criando Theme Customization ==========")
+	ctx = s.beginStep(runCtx, "Passo 16: Criando Theme Customization")
 	if s.seedData.ThemeCustomization.PrimaryColor != "" {
-		err := s.client.CreateThemeCustomization(&s.seedData.ThemeCustomization)
+		err := s.client.CreateThemeCustomization(ctx, &s.seedData.ThemeCustomization)
 		if err != nil {
 			s.logger.Error(fmt.Sprintf("Erro ao criar theme customization: %v", err))
 			s.state.failed++
-			s.state.errors = append(s.state.errors, SeedError{
-				Type:    "theme",
-				Item:    "theme_customization",
-				Message: err.Error(),
-			})
+			s.state.errors = append(s.state.errors, newSeedError("theme", "theme_customization", err))
 		} else {
 			s.logger.Info("Theme Customization criado/atualizado com sucesso")
 			s.state.created++
@@ -724,16 +892,116 @@ func (s *SeedServiceV2) Execute(ctx context.Context) error {
 		s.logger.Info("Nenhum ThemeCustomization definido no seed")
 	}
 
-	return nil
+	// Auditar o tema efetivamente ativo no projeto (pode ter sido alterado fora do seed)
+	s.auditLiveTheme(ctx)
+
+	// PASSO 17: Display Settings
+	ctx = s.beginStep(runCtx, "Passo 17: Aplicando Display Settings")
+	switch {
+	case s.seedData.ResetDisplaySettings:
+		if err := s.client.ResetDisplaySettings(ctx); err != nil {
+			s.recordStepError("display_settings", "reset", err)
+		} else {
+			s.logger.Info("Display settings restaurados para o padrão")
+			s.state.updated++
+		}
+	case s.seedData.DisplaySettings != nil:
+		if err := s.client.UpdateDisplaySettings(ctx, s.seedData.DisplaySettings); err != nil {
+			s.recordStepError("display_settings", "project_display_settings", err)
+		} else {
+			s.logger.Info("Display settings atualizados com sucesso")
+			s.state.updated++
+		}
+	default:
+		s.logger.Info("Nenhum DisplaySettings definido no seed")
+	}
+
+	// PASSO 18: Override manual de menu
+	ctx = s.beginStep(runCtx, "Passo 18: Aplicando Override de Menu")
+	switch {
+	case s.seedData.ResetMenuOverride:
+		if err := s.client.ClearMenuManualOverride(ctx); err != nil {
+			s.recordStepError("menu_override", "reset", err)
+		} else {
+			s.logger.Info("Override manual removido, seleção automática de menu ativa")
+			s.state.updated++
+		}
+	case s.seedData.MenuOverride != nil:
+		ref := s.seedData.MenuOverride.MenuIDRef
+		menuID, ok := menuIDs[ref]
+		if !ok {
+			s.recordStepError("menu_override", fmt.Sprintf("menu_id_ref %d", ref), fmt.Errorf("menu não encontrado"))
+		} else if err := s.client.SetMenuManualOverride(ctx, menuID); err != nil {
+			s.recordStepError("menu_override", s.seedData.Menus[ref].Name, err)
+		} else {
+			s.logger.Info("Menu %s definido como override manual", s.seedData.Menus[ref].Name)
+			s.state.updated++
+		}
+	default:
+		s.logger.Info("Nenhum MenuOverride definido no seed")
+	}
+
+	ids := map[string]map[int]string{
+		"menu":        menuIDs,
+		"category":    categoryIDs,
+		"subcategory": subcategoryIDs,
+		"environment": envIDs,
+		"table":       tableIDs,
+		"product":     productIDs,
+		"tag":         tagIDs,
+	}
+
+	// PASSO 19: Sincronização (apenas com -sync)
+	if s.config.Sync.Enabled {
+		ctx = s.beginStep(runCtx, "Passo 19: Sincronizando Catálogo")
+		s.syncProject(ctx, ids)
+	}
+
+	ids["user"] = userIDs
+	ids["customer"] = customerIDs
+	ids["reservation"] = reservationIDs
+	s.recordResolvedIDs(ids)
+}
+
+// recordStepError registra a falha de um passo de configuração
+func (s *SeedServiceV2) recordStepError(errType, item string, err error) {
+	s.logger.With(Fields{"entity": errType}).Error("Erro em %s (%s): %v", errType, item, err)
+	s.state.failed++
+	s.state.errors = append(s.state.errors, newSeedError(errType, item, err))
+}
+
+// auditLiveTheme verifica o contraste do tema retornado pelo backend.
+// Em modo estrito cada par reprovado conta como erro.
+func (s *SeedServiceV2) auditLiveTheme(ctx context.Context) {
+	light, dark, err := s.client.GetProjectTheme(ctx)
+	if err != nil {
+		s.logger.Warn("Não foi possível auditar o tema do projeto: %v", err)
+		return
+	}
+
+	issues := AuditThemeContrast(light, dark)
+	if !logContrastIssues(s.logger, "projeto", issues) || !s.config.Seed.StrictContrast {
+		return
+	}
+
+	for _, issue := range issues {
+		s.state.failed++
+		s.state.errors = append(s.state.errors, SeedError{
+			Type:    "theme_contrast",
+			Item:    issue.Mode + " " + issue.Pair,
+			Message: issue.String(),
+		})
+	}
 }
 
 // createOrganization cria organização ou faz login se existir
-func (s *SeedServiceV2) createOrganization() (orgID, projID, email string, err error) {
+func (s *SeedServiceV2) createOrganization(ctx context.Context) (orgID, projID, email string, err error) {
 	email = s.config.GetAutoEmail()
 	password := "senha123"
 
 	// Tentar criar
 	orgID, projID, err = s.client.CreateOrganization(
+		ctx,
 		s.config.Auth.OrganizationName,
 		email,
 		password,
@@ -743,13 +1011,14 @@ func (s *SeedServiceV2) createOrganization() (orgID, projID, email string, err e
 		// Se falhou, tentar login com o email que tentamos criar
 		// (pois a organização pode já existir com essas credenciais)
 		s.logger.Info(fmt.Sprintf("Organização pode já existir, tentando login com %s", email))
-		orgID, projID, err = s.client.LoginAndGetIDs(email, password)
+		orgID, projID, err = s.client.LoginAndGetIDs(ctx, email, password)
 
 		if err != nil {
 			// Se ainda falhar, tentar com fallback
 			// IMPORTANTE: Usar LoginAndGetIDsForOrg para buscar especificamente a organização "LEP Fattoria"
 			s.logger.Info(fmt.Sprintf("Tentando fallback com %s para organização '%s'", s.config.Auth.FallbackEmail, s.config.Auth.OrganizationName))
 			orgID, projID, err = s.client.LoginAndGetIDsForOrg(
+				ctx,
 				s.config.Auth.FallbackEmail,
 				s.config.Auth.FallbackPassword,
 				s.config.Auth.OrganizationName,
@@ -764,10 +1033,10 @@ func (s *SeedServiceV2) createOrganization() (orgID, projID, email string, err e
 }
 
 // login faz login de um usuário
-func (s *SeedServiceV2) login(email string) error {
+func (s *SeedServiceV2) login(ctx context.Context, email string) error {
 	password := "senha123"
 
-	_, _, err := s.client.LoginAndGetIDs(email, password)
+	_, _, err := s.client.LoginAndGetIDs(ctx, email, password)
 	return err
 }
 
@@ -782,6 +1051,9 @@ func LoadSeedData(filename string) (*SeedData, error) {
 	if err := json.Unmarshal(data, &seedData); err != nil {
 		return nil, fmt.Errorf("erro ao parsear JSON: %w", err)
 	}
+	if err := seedData.detectNullSections(data); err != nil {
+		return nil, err
+	}
 
 	return &seedData, nil
 }
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// membershipScope descreve os endpoints de vínculo usuário-organização ou usuário-projeto
+type membershipScope struct {
+	base          string // /user-organization ou /user-project
+	targetField   string // campo com o ID da organização/projeto na listagem
+	removeSegment string // segmento do DELETE: /user/{id}/{segment}/{targetId}
+}
+
+var membershipScopes = map[string]membershipScope{
+	"organization": {base: "/user-organization", targetField: "organization_id", removeSegment: "org"},
+	"project":      {base: "/user-project", targetField: "project_id", removeSegment: "proj"},
+}
+
+// UserMembership é um vínculo existente no backend
+type UserMembership struct {
+	ID       string
+	TargetID string
+	Role     string
+	Fields   map[string]interface{} // vínculo como listado, base do PUT
+}
+
+// ListUserMemberships lista os vínculos do usuário no escopo (GET /user-organization/user/{id})
+func (c *APIClientV2) ListUserMemberships(ctx context.Context, scope, userID string) ([]UserMembership, error) {
+	sc := membershipScopes[scope]
+	items, err := c.listAll(ctx, fmt.Sprintf("%s/user/%s", sc.base, userID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	memberships := make([]UserMembership, 0, len(items))
+	for _, item := range items {
+		m := UserMembership{Fields: item}
+		m.ID, _ = item["id"].(string)
+		m.TargetID, _ = item[sc.targetField].(string)
+		m.Role, _ = item["role"].(string)
+		if m.TargetID != "" {
+			memberships = append(memberships, m)
+		}
+	}
+	return memberships, nil
+}
+
+// AddUserMembership vincula o usuário à organização/projeto com o papel informado
+func (c *APIClientV2) AddUserMembership(ctx context.Context, scope, userID, targetID, role string) error {
+	sc := membershipScopes[scope]
+	payload := map[string]interface{}{
+		sc.targetField: targetID,
+		"role":         role,
+	}
+
+	resp, status, err := c.doRequest(ctx, "POST", fmt.Sprintf("%s/user/%s", sc.base, userID), payload)
+	if err != nil {
+		return err
+	}
+
+	if status == 409 {
+		return nil // Vínculo já existe
+	}
+
+	if status != 200 && status != 201 {
+		return newAPIError(status, resp)
+	}
+
+	return nil
+}
+
+// UpdateUserMembership altera o papel de um vínculo existente (PUT /user-organization/{id}).
+// O PUT substitui o vínculo inteiro, então o papel é mesclado ao vínculo listado.
+func (c *APIClientV2) UpdateUserMembership(ctx context.Context, scope string, m UserMembership, role string) error {
+	sc := membershipScopes[scope]
+	payload := mergeFields(m.Fields, map[string]interface{}{"role": role})
+
+	resp, status, err := c.doRequest(ctx, "PUT", fmt.Sprintf("%s/%s", sc.base, m.ID), payload)
+	if err != nil {
+		return err
+	}
+
+	if status != 200 && status != 201 {
+		return newAPIError(status, resp)
+	}
+
+	return nil
+}
+
+// RemoveUserMembership remove o acesso do usuário à organização/projeto
+func (c *APIClientV2) RemoveUserMembership(ctx context.Context, scope, userID, targetID string) error {
+	sc := membershipScopes[scope]
+
+	resp, status, err := c.doRequest(ctx, "DELETE", fmt.Sprintf("%s/user/%s/%s/%s", sc.base, userID, sc.removeSegment, targetID), nil)
+	if err != nil {
+		return err
+	}
+
+	if status == 404 {
+		return nil // Vínculo já não existe
+	}
+
+	if status != 200 && status != 204 {
+		return newAPIError(status, resp)
+	}
+
+	return nil
+}
+
+// ValidateMemberships verifica escopo e papel dos vínculos declarados
+func (s *SeedData) ValidateMemberships() error {
+	for i, user := range s.Users {
+		for j, m := range user.Memberships {
+			where := fmt.Sprintf("users[%d].memberships[%d]", i, j)
+			if _, ok := membershipScopes[m.Scope]; !ok {
+				return fmt.Errorf("%s: scope %q inválido (use organization ou project)", where, m.Scope)
+			}
+			if m.Role == "" {
+				return fmt.Errorf("%s: role é obrigatório", where)
+			}
+		}
+	}
+	return nil
+}
+
+// resolveMembershipTarget encontra o ID da organização/projeto do vínculo
+func (s *SeedServiceV2) resolveMembershipTarget(ctx context.Context, m UserMembershipData) (string, error) {
+	switch m.Scope {
+	case "organization":
+		if m.Name == "" || m.Name == s.config.Auth.OrganizationName || m.Name == s.seedData.Organization.Name {
+			return s.client.orgID, nil
+		}
+		id, err := s.client.findByField(ctx, "/organization", "name", m.Name)
+		if err != nil {
+			return "", fmt.Errorf("organização %q: %w", m.Name, err)
+		}
+		return id.String(), nil
+	default:
+		if m.Name == "" {
+			return s.client.projID, nil
+		}
+		id, err := s.client.findByField(ctx, "/project", "name", m.Name)
+		if err != nil {
+			return "", fmt.Errorf("projeto %q: %w", m.Name, err)
+		}
+		return id.String(), nil
+	}
+}
+
+// reconcileMemberships cria os vínculos ausentes e corrige papéis divergentes.
+// Com seed.prune_links, vínculos com a organização e o projeto do seed que não foram declarados são removidos;
+// vínculos com outras organizações/projetos nunca são tocados.
+func (s *SeedServiceV2) reconcileMemberships(ctx context.Context, user UserData, userID string) {
+	if len(user.Memberships) == 0 {
+		return
+	}
+
+	for _, scope := range []string{"organization", "project"} {
+		wanted := make(map[string]string) // targetID -> role
+		for _, m := range user.Memberships {
+			if m.Scope != scope {
+				continue
+			}
+			targetID, err := s.resolveMembershipTarget(ctx, m)
+			if err != nil {
+				s.recordMembershipError(user, scope, err)
+				continue
+			}
+			wanted[targetID] = m.Role
+		}
+
+		current, err := s.client.ListUserMemberships(ctx, scope, userID)
+		if err != nil {
+			// Sem a listagem só é possível garantir os vínculos (o POST ignora os existentes)
+			s.logger.Debug("Não foi possível listar vínculos (%s) de %s: %v", scope, user.Email, err)
+			current = nil
+		}
+
+		existing := make(map[string]UserMembership, len(current))
+		for _, m := range current {
+			existing[m.TargetID] = m
+		}
+
+		for targetID, role := range wanted {
+			m, ok := existing[targetID]
+			switch {
+			case !ok:
+				if err := s.client.AddUserMembership(ctx, scope, userID, targetID, role); err != nil {
+					s.recordMembershipError(user, scope, err)
+					continue
+				}
+				s.logger.Info("Usuário %s vinculado (%s %s) como %s", user.Email, scope, targetID, role)
+			case m.Role != role && m.ID != "":
+				if err := s.client.UpdateUserMembership(ctx, scope, m, role); err != nil {
+					s.recordMembershipError(user, scope, err)
+					continue
+				}
+				s.logger.Info("Papel de %s em %s %s: %s -> %s", user.Email, scope, targetID, m.Role, role)
+				s.state.updated++
+			}
+		}
+
+		if !s.config.Seed.PruneLinks {
+			continue
+		}
+
+		seeded := s.client.orgID
+		if scope == "project" {
+			seeded = s.client.projID
+		}
+		if _, declared := wanted[seeded]; declared {
+			continue
+		}
+		if _, linked := existing[seeded]; !linked {
+			continue
+		}
+		if err := s.client.RemoveUserMembership(ctx, scope, userID, seeded); err != nil {
+			s.recordMembershipError(user, scope, err)
+			continue
+		}
+		s.logger.Info("Usuário %s desvinculado de %s %s (fora do seed)", user.Email, scope, seeded)
+	}
+}
+
+func (s *SeedServiceV2) recordMembershipError(user UserData, scope string, err error) {
+	s.logger.With(Fields{"entity": "user_membership"}).Error("Erro no vínculo (%s) do usuário %s: %v", scope, user.Email, err)
+	s.state.failed++
+	s.state.errors = append(s.state.errors, newSeedError("user_membership", user.Email, err))
+}
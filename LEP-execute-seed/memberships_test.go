@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestUpdateUserMembershipKeepsLink(t *testing.T) {
+	const (
+		userID   = "0b000000-0000-0000-0000-000000000001"
+		targetID = "0c000000-0000-0000-0000-000000000001"
+		linkID   = "0d000000-0000-0000-0000-000000000001"
+	)
+
+	tests := []struct {
+		scope    string
+		listPath string
+		putPath  string
+		field    string
+	}{
+		{scope: "organization", listPath: "/user-organization/user/" + userID, putPath: "/user-organization/" + linkID, field: "organization_id"},
+		{scope: "project", listPath: "/user-project/user/" + userID, putPath: "/user-project/" + linkID, field: "project_id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scope, func(t *testing.T) {
+			link := map[string]interface{}{"id": linkID, "user_id": userID, tt.field: targetID, "role": "member", "active": true}
+
+			var putPath string
+			var put map[string]interface{}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPut:
+					putPath = r.URL.Path
+					body, _ := io.ReadAll(r.Body)
+					json.Unmarshal(body, &put)
+					w.Write([]byte(`{}`))
+				case r.URL.Path == tt.listPath:
+					json.NewEncoder(w).Encode(map[string]interface{}{"data": []interface{}{link}})
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer srv.Close()
+
+			cfg := &Config{}
+			cfg.Retry.MaxAttempts = 1
+			client := NewAPIClientV2(srv.URL, NewLogger(false), cfg)
+
+			memberships, err := client.ListUserMemberships(context.Background(), tt.scope, userID)
+			if err != nil || len(memberships) != 1 {
+				t.Fatalf("ListUserMemberships() = %v, %v", memberships, err)
+			}
+			if err := client.UpdateUserMembership(context.Background(), tt.scope, memberships[0], "admin"); err != nil {
+				t.Fatalf("UpdateUserMembership() erro = %v", err)
+			}
+
+			want := map[string]interface{}{"id": linkID, "user_id": userID, tt.field: targetID, "role": "admin", "active": true}
+			if putPath != tt.putPath || !reflect.DeepEqual(put, want) {
+				t.Errorf("PUT %s %v, want PUT %s %v", putPath, put, tt.putPath, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,554 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// migrationEntities são as coleções que uma migration pode alterar
+var migrationEntities = map[string]bool{
+	"menu": true, "category": true, "subcategory": true, "product": true, "tag": true,
+	"table": true, "environment": true, "customer": true, "reservation": true,
+}
+
+// migrationFilePattern casa arquivos NNNN_descricao.json
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([A-Za-z0-9_-]+)\.json$`)
+
+// defaultProjectAlias identifica o projeto do login em Migration.Projects
+const defaultProjectAlias = "default"
+
+// Migration é um conjunto ordenado de operações aplicado uma única vez por projeto
+type Migration struct {
+	Version     int                  `json:"-"`
+	Name        string               `json:"-"`
+	Checksum    string               `json:"-"`
+	Description string               `json:"description"`
+	Projects    []string             `json:"projects,omitempty"` // vazio = todos; "default" = projeto do login
+	Operations  []MigrationOperation `json:"operations"`
+}
+
+// MigrationOperation é uma operação create, update, delete, link ou unlink.
+//
+//	{"op": "update", "entity": "category", "match": {"name": "Massas"}, "set": {"name": "Massas Frescas"}}
+//	{"op": "update", "entity": "product", "match": {"name": "Risoto"}, "set_refs": {"subcategory_id": {"entity": "subcategory", "match": {"name": "Risotos"}}}}
+//	{"op": "link", "entity": "product", "match": {"name": "Risoto"}, "target": {"entity": "tag", "match": {"name": "Vegano"}}}
+type MigrationOperation struct {
+	Op      string                  `json:"op"`
+	Entity  string                  `json:"entity"`
+	Match   map[string]interface{}  `json:"match,omitempty"`
+	Set     map[string]interface{}  `json:"set,omitempty"`
+	SetRefs map[string]MigrationRef `json:"set_refs,omitempty"`
+	Target  *MigrationRef           `json:"target,omitempty"`
+}
+
+// MigrationRef aponta para outra entidade pelos valores de seus campos
+type MigrationRef struct {
+	Entity string                 `json:"entity"`
+	Match  map[string]interface{} `json:"match"`
+}
+
+// LoadMigrations lê e ordena as migrations do diretório; diretório inexistente não tem migrations
+func LoadMigrations(dir string) ([]*Migration, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler diretório de migrations: %w", err)
+	}
+
+	var migrations []*Migration
+	versions := make(map[int]string)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		m := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if m == nil {
+			return nil, fmt.Errorf("migration %s: nome deve seguir NNNN_descricao.json", entry.Name())
+		}
+
+		version, _ := strconv.Atoi(m[1])
+		if other, dup := versions[version]; dup {
+			return nil, fmt.Errorf("migrations %s e %s têm a mesma versão %d", other, entry.Name(), version)
+		}
+		versions[version] = entry.Name()
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler migration %s: %w", entry.Name(), err)
+		}
+
+		migration := &Migration{}
+		if err := json.Unmarshal(data, migration); err != nil {
+			return nil, fmt.Errorf("erro ao parsear migration %s: %w", entry.Name(), err)
+		}
+		sum := sha256.Sum256(data)
+		migration.Version = version
+		migration.Name = m[2]
+		migration.Checksum = hex.EncodeToString(sum[:])
+
+		if err := migration.Validate(); err != nil {
+			return nil, fmt.Errorf("migration %s: %w", entry.Name(), err)
+		}
+		migrations = append(migrations, migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Validate verifica as operações antes de qualquer chamada ao backend
+func (m *Migration) Validate() error {
+	if len(m.Operations) == 0 {
+		return fmt.Errorf("nenhuma operação")
+	}
+
+	for i, op := range m.Operations {
+		where := fmt.Sprintf("operations[%d]", i)
+		if !migrationEntities[op.Entity] {
+			return fmt.Errorf("%s: entity %q não suportada", where, op.Entity)
+		}
+
+		switch op.Op {
+		case "create":
+			if len(op.Set) == 0 {
+				return fmt.Errorf("%s: create exige set", where)
+			}
+		case "update":
+			if len(op.Match) == 0 || len(op.Set)+len(op.SetRefs) == 0 {
+				return fmt.Errorf("%s: update exige match e set ou set_refs", where)
+			}
+		case "delete":
+			if len(op.Match) == 0 {
+				return fmt.Errorf("%s: delete exige match", where)
+			}
+		case "link", "unlink":
+			if len(op.Match) == 0 || op.Target == nil || len(op.Target.Match) == 0 {
+				return fmt.Errorf("%s: %s exige match e target", where, op.Op)
+			}
+			if !migrationEntities[op.Target.Entity] {
+				return fmt.Errorf("%s: target %q não suportado", where, op.Target.Entity)
+			}
+		default:
+			return fmt.Errorf("%s: op %q inválida (use create, update, delete, link ou unlink)", where, op.Op)
+		}
+
+		for field, ref := range op.SetRefs {
+			if !migrationEntities[ref.Entity] || len(ref.Match) == 0 {
+				return fmt.Errorf("%s: set_refs.%s precisa de entity e match válidos", where, field)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Label identifica a migration em logs ("0003_renomear_massas")
+func (m *Migration) Label() string {
+	return fmt.Sprintf("%04d_%s", m.Version, m.Name)
+}
+
+// deletes indica se a migration apaga entidades ou vínculos
+func (m *Migration) deletes() bool {
+	for _, op := range m.Operations {
+		if op.Op == "delete" || op.Op == "unlink" {
+			return true
+		}
+	}
+	return false
+}
+
+// appliesTo indica se a migration deve rodar no projeto
+func (m *Migration) appliesTo(projectName string) bool {
+	if len(m.Projects) == 0 {
+		return true
+	}
+	for _, name := range m.Projects {
+		if name == projectName {
+			return true
+		}
+	}
+	return false
+}
+
+// migrationLedger registra as versões aplicadas por projeto e o progresso de uma migration interrompida
+type migrationLedger struct {
+	path       string
+	Projects   map[string][]appliedMigration `json:"projects"`              // projID -> migrations aplicadas
+	InProgress map[string]migrationProgress  `json:"in_progress,omitempty"` // projID -> migration aplicada em parte
+}
+
+// migrationProgress guarda quantas operações da migration já foram aplicadas,
+// para que a próxima execução retome da operação que falhou em vez de repetir as anteriores
+type migrationProgress struct {
+	Version   int    `json:"version"`
+	Checksum  string `json:"checksum"`
+	Completed int    `json:"completed"`
+}
+
+type appliedMigration struct {
+	Version   int       `json:"version"`
+	Name      string    `json:"name"`
+	Checksum  string    `json:"checksum"`
+	AppliedAt time.Time `json:"applied_at"`
+}
+
+// loadMigrationLedger carrega o ledger; arquivo inexistente resulta em ledger vazio
+func loadMigrationLedger(path string) (*migrationLedger, error) {
+	ledger := &migrationLedger{path: path, Projects: make(map[string][]appliedMigration), InProgress: make(map[string]migrationProgress)}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ledger, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler ledger de migrations: %w", err)
+	}
+
+	if err := json.Unmarshal(data, ledger); err != nil {
+		return nil, fmt.Errorf("erro ao parsear ledger de migrations: %w", err)
+	}
+	if ledger.Projects == nil {
+		ledger.Projects = make(map[string][]appliedMigration)
+	}
+	if ledger.InProgress == nil {
+		ledger.InProgress = make(map[string]migrationProgress)
+	}
+
+	return ledger, nil
+}
+
+// applied retorna o registro da versão no projeto, se já aplicada
+func (l *migrationLedger) applied(projID string, version int) (appliedMigration, bool) {
+	for _, a := range l.Projects[projID] {
+		if a.Version == version {
+			return a, true
+		}
+	}
+	return appliedMigration{}, false
+}
+
+// progress retorna quantas operações da migration já foram aplicadas no projeto.
+// Um arquivo alterado depois de aplicado em parte não pode ser retomado com segurança.
+func (l *migrationLedger) progress(projID string, m *Migration) (int, error) {
+	p, ok := l.InProgress[projID]
+	if !ok || p.Version != m.Version {
+		return 0, nil
+	}
+	if p.Checksum != m.Checksum {
+		return 0, fmt.Errorf("arquivo alterado depois de %d operação(ões) aplicada(s); restaure o arquivo original ou registre o estado em %s", p.Completed, l.path)
+	}
+	return p.Completed, nil
+}
+
+// recordProgress grava que as primeiras completed operações da migration foram aplicadas
+func (l *migrationLedger) recordProgress(projID string, m *Migration, completed int) error {
+	l.InProgress[projID] = migrationProgress{Version: m.Version, Checksum: m.Checksum, Completed: completed}
+	return l.save()
+}
+
+// record marca a migration como aplicada e grava o ledger imediatamente,
+// para que uma falha posterior não faça a migration rodar de novo
+func (l *migrationLedger) record(projID string, m *Migration) error {
+	l.Projects[projID] = append(l.Projects[projID], appliedMigration{
+		Version:   m.Version,
+		Name:      m.Name,
+		Checksum:  m.Checksum,
+		AppliedAt: time.Now().UTC(),
+	})
+	delete(l.InProgress, projID)
+	return l.save()
+}
+
+// save grava o ledger de migrations
+func (l *migrationLedger) save() error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("erro ao serializar ledger de migrations: %w", err)
+	}
+	if err := os.WriteFile(l.path, data, 0644); err != nil {
+		return fmt.Errorf("erro ao gravar ledger de migrations: %w", err)
+	}
+	return nil
+}
+
+// findEntityByMatch retorna o ID da única entidade da coleção cujos campos têm os valores informados
+func (c *APIClientV2) findEntityByMatch(ctx context.Context, entity string, match map[string]interface{}) (string, map[string]interface{}, error) {
+	items, err := c.listAll(ctx, "/"+entity, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var found []map[string]interface{}
+	for _, item := range items {
+		if matchesFields(item, match) {
+			found = append(found, item)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return "", nil, fmt.Errorf("%s %v não encontrado", entity, match)
+	case 1:
+		id, _ := found[0]["id"].(string)
+		return id, found[0], nil
+	default:
+		return "", nil, fmt.Errorf("%s %v é ambíguo (%d entidades)", entity, match, len(found))
+	}
+}
+
+// matchesFields compara os campos pela forma JSON (números do backend chegam como float64)
+func matchesFields(item, match map[string]interface{}) bool {
+	for field, want := range match {
+		if !syncValueEqual(item[field], want) {
+			return false
+		}
+	}
+	return true
+}
+
+// applyMigrationOperation executa uma operação no projeto atual do client
+func (c *APIClientV2) applyMigrationOperation(ctx context.Context, op MigrationOperation) error {
+	path := "/" + op.Entity
+
+	switch op.Op {
+	case "create":
+		resp, status, err := c.doRequest(ctx, "POST", path, op.Set)
+		if err != nil {
+			return err
+		}
+		if status != 200 && status != 201 {
+			return newAPIError(status, resp)
+		}
+		c.cache.invalidate(c.cacheKey(path))
+		return nil
+
+	case "update":
+		id, current, err := c.findEntityByMatch(ctx, op.Entity, op.Match)
+		if err != nil {
+			return err
+		}
+		changes := make(map[string]interface{}, len(op.Set)+len(op.SetRefs))
+		for k, v := range op.Set {
+			changes[k] = v
+		}
+		for field, ref := range op.SetRefs {
+			refID, _, err := c.findEntityByMatch(ctx, ref.Entity, ref.Match)
+			if err != nil {
+				return fmt.Errorf("set_refs.%s: %w", field, err)
+			}
+			changes[field] = refID
+		}
+		return c.UpdateEntity(ctx, path, id, mergeFields(current, changes))
+
+	case "delete":
+		id, _, err := c.findEntityByMatch(ctx, op.Entity, op.Match)
+		if err != nil {
+			return err
+		}
+		return c.DeleteEntity(ctx, path, id)
+
+	default: // link, unlink
+		id, _, err := c.findEntityByMatch(ctx, op.Entity, op.Match)
+		if err != nil {
+			return err
+		}
+		targetID, _, err := c.findEntityByMatch(ctx, op.Target.Entity, op.Target.Match)
+		if err != nil {
+			return fmt.Errorf("target: %w", err)
+		}
+
+		linkPath := fmt.Sprintf("%s/%s/%s/%s", path, id, op.Target.Entity, targetID)
+		method, body := "POST", map[string]interface{}{op.Target.Entity + "_id": targetID}
+		if op.Op == "unlink" {
+			method, body = "DELETE", nil
+		}
+
+		resp, status, err := c.doRequest(ctx, method, linkPath, body)
+		if err != nil {
+			return err
+		}
+		if status == 409 || (op.Op == "unlink" && status == 404) {
+			return nil // Vínculo já no estado desejado
+		}
+		if status != 200 && status != 201 && status != 204 {
+			return newAPIError(status, resp)
+		}
+		return nil
+	}
+}
+
+// migrationTarget é um projeto no qual as migrations são aplicadas
+type migrationTarget struct {
+	name   string // "default" para o projeto do login
+	projID string
+}
+
+// runMigrations executa o comando migrate: "status" lista versões; "up" (padrão) aplica as pendentes.
+// projects são os projetos declarados nos arquivos de seed, além do projeto do login.
+// Retorna o código de saída.
+func (s *SeedServiceV2) runMigrations(ctx context.Context, action string, projects []string) int {
+	if action != "up" && action != "status" {
+		s.logger.Error("Comando desconhecido: migrate %s (use up ou status)", action)
+		return exitUsage
+	}
+
+	migrations, err := LoadMigrations(s.config.Migrations.Dir)
+	if err != nil {
+		s.logger.Error("%v", err)
+		return exitValidation
+	}
+	if len(migrations) == 0 {
+		s.logger.Info("Nenhuma migration em %s", s.config.Migrations.Dir)
+		return exitOK
+	}
+
+	ledger, err := loadMigrationLedger(s.config.Migrations.Ledger)
+	if err != nil {
+		s.logger.Error("%v", err)
+		return exitValidation
+	}
+
+	orgID, projID, email, err := s.createOrganization(ctx)
+	if err == nil {
+		err = s.login(ctx, email)
+	}
+	if err != nil {
+		s.logger.Error("Erro ao autenticar: %v", err)
+		return exitAuth
+	}
+	s.client.SetHeaders(s.client.token, orgID, projID)
+
+	targets := s.migrationTargets(ctx, projID, projects)
+
+	if action == "status" {
+		s.printMigrationStatus(migrations, ledger, targets)
+		return exitOK
+	}
+
+	// Em ambiente protegido, uma migration pendente que apaga dados bloqueia o comando inteiro
+	for _, target := range targets {
+		for _, m := range migrations {
+			if _, done := ledger.applied(target.projID, m.Version); done || !m.appliesTo(target.name) || !m.deletes() {
+				continue
+			}
+			if err := s.config.CheckDeletesAllowed("Migration " + m.Label()); err != nil {
+				s.logger.Error("%v", err)
+				return exitUsage
+			}
+		}
+	}
+
+	failed := false
+	for _, target := range targets {
+		s.logger.Section("Migrations: projeto " + target.name)
+		s.client.SetHeaders(s.client.token, orgID, target.projID)
+
+		for _, m := range migrations {
+			if !m.appliesTo(target.name) {
+				continue
+			}
+			if _, done := ledger.applied(target.projID, m.Version); done {
+				continue
+			}
+
+			if err := s.applyMigration(ctx, m, ledger, target.projID); err != nil {
+				s.logger.Error("Migration %s falhou no projeto %s: %v", m.Label(), target.name, err)
+				if ctx.Err() != nil {
+					s.logger.Warn("Execução interrompida, migrations pendentes não foram aplicadas")
+					return exitInterrupt
+				}
+				s.logger.Error("Migrations seguintes deste projeto não foram aplicadas")
+				failed = true
+				break
+			}
+			if err := ledger.record(target.projID, m); err != nil {
+				s.logger.Error("%v", err)
+				return exitPartial
+			}
+			s.logger.Info("Migration aplicada: %s", m.Label())
+		}
+	}
+
+	if failed {
+		return exitPartial
+	}
+	return exitOK
+}
+
+// applyMigration executa as operações da migration em ordem, parando na primeira falha.
+// Cada operação concluída é gravada no ledger; uma migration aplicada em parte é retomada
+// da operação que falhou, sem repetir as anteriores.
+func (s *SeedServiceV2) applyMigration(ctx context.Context, m *Migration, ledger *migrationLedger, projID string) error {
+	start, err := ledger.progress(projID, m)
+	if err != nil {
+		return err
+	}
+
+	if start > 0 {
+		s.logger.Info("Retomando %s a partir de operations[%d]: %s", m.Label(), start, m.Description)
+	} else {
+		s.logger.Info("Aplicando %s: %s", m.Label(), m.Description)
+	}
+	for i := start; i < len(m.Operations); i++ {
+		op := m.Operations[i]
+		if err := s.client.applyMigrationOperation(ctx, op); err != nil {
+			return fmt.Errorf("operations[%d] (%s %s): %w", i, op.Op, op.Entity, err)
+		}
+		if err := ledger.recordProgress(projID, m, i+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrationTargets retorna o projeto do login e os projetos declarados que já existem no backend
+func (s *SeedServiceV2) migrationTargets(ctx context.Context, defaultProjID string, projects []string) []migrationTarget {
+	targets := []migrationTarget{{name: defaultProjectAlias, projID: defaultProjID}}
+
+	for _, name := range projects {
+		id, err := s.client.GetProjectByName(ctx, name)
+		if err != nil {
+			s.logger.Warn("Projeto %s ainda não existe, migrations não se aplicam a ele", name)
+			continue
+		}
+		targets = append(targets, migrationTarget{name: name, projID: id.String()})
+	}
+	return targets
+}
+
+// printMigrationStatus lista versões aplicadas e pendentes por projeto
+func (s *SeedServiceV2) printMigrationStatus(migrations []*Migration, ledger *migrationLedger, targets []migrationTarget) {
+	for _, target := range targets {
+		s.logger.Section(fmt.Sprintf("Projeto %s (%s)", target.name, target.projID))
+		for _, m := range migrations {
+			if !m.appliesTo(target.name) {
+				continue
+			}
+			log := s.logger.With(Fields{"project": target.name, "version": m.Version})
+			a, done := ledger.applied(target.projID, m.Version)
+			partial, hasPartial := ledger.InProgress[target.projID]
+			switch {
+			case !done && hasPartial && partial.Version == m.Version:
+				log.Warn("[~] %s  aplicada em parte (%d de %d operações)", m.Label(), partial.Completed, len(m.Operations))
+			case !done:
+				log.Info("[ ] %s  pendente", m.Label())
+			case a.Checksum != m.Checksum:
+				log.Warn("[✓] %s  aplicada em %s (arquivo alterado depois de aplicado)", m.Label(), a.AppliedAt.Format(time.RFC3339))
+			default:
+				log.Info("[✓] %s  aplicada em %s", m.Label(), a.AppliedAt.Format(time.RFC3339))
+			}
+		}
+	}
+}
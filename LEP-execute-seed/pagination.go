@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// lookupFilters mapeia coleções para o filtro server-side que restringe a busca pelo valor procurado.
+// O resultado filtrado ainda é comparado exatamente no cliente, então um backend que ignore o filtro continua correto.
+var lookupFilters = map[string]map[string]string{
+	"/customer": {"email": "search"},
+}
+
+// pageIterator percorre uma coleção paginada do backend (?page=N&limit=M) até esgotá-la
+type pageIterator struct {
+	client   *APIClientV2
+	path     string
+	filters  url.Values
+	pageSize int
+	page     int
+	done     bool
+	firstID  string // primeiro ID da página anterior, para detectar backend que ignora ?page
+}
+
+// paginate cria iterador para a coleção com filtros opcionais
+func (c *APIClientV2) paginate(path string, filters url.Values) *pageIterator {
+	pageSize := c.config.Seed.PageSize
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+
+	return &pageIterator{
+		client:   c,
+		path:     path,
+		filters:  filters,
+		pageSize: pageSize,
+		page:     1,
+	}
+}
+
+// Next retorna a próxima página; retorna nil quando não há mais páginas
+func (it *pageIterator) Next(ctx context.Context) ([]map[string]interface{}, error) {
+	if it.done {
+		return nil, nil
+	}
+
+	query := url.Values{}
+	for k, v := range it.filters {
+		query[k] = v
+	}
+	query.Set("page", strconv.Itoa(it.page))
+	query.Set("limit", strconv.Itoa(it.pageSize))
+
+	sep := "?"
+	if strings.Contains(it.path, "?") {
+		sep = "&"
+	}
+
+	resp, status, err := it.client.doRequest(ctx, "GET", it.path+sep+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, newAPIError(status, resp)
+	}
+
+	var items []map[string]interface{}
+	if data, ok := resp["data"].([]interface{}); ok {
+		for _, d := range data {
+			if item, ok := d.(map[string]interface{}); ok {
+				items = append(items, item)
+			}
+		}
+	}
+
+	// Backend sem suporte a paginação devolve a mesma lista completa em todas as páginas
+	if len(items) > 0 {
+		firstID, _ := items[0]["id"].(string)
+		if it.page > 1 && firstID != "" && firstID == it.firstID {
+			it.done = true
+			return nil, nil
+		}
+		it.firstID = firstID
+	}
+
+	if len(items) < it.pageSize || isLastPage(resp, it.page) {
+		it.done = true
+	}
+	it.page++
+
+	return items, nil
+}
+
+// isLastPage lê metadados de paginação quando o backend os envia
+// (total_pages/totalPages na raiz ou em "pagination"/"meta")
+func isLastPage(resp map[string]interface{}, page int) bool {
+	candidates := []map[string]interface{}{resp}
+	for _, key := range []string{"pagination", "meta"} {
+		if meta, ok := resp[key].(map[string]interface{}); ok {
+			candidates = append(candidates, meta)
+		}
+	}
+
+	for _, meta := range candidates {
+		for _, key := range []string{"total_pages", "totalPages"} {
+			if total, ok := meta[key].(float64); ok {
+				return page >= int(total)
+			}
+		}
+		if hasNext, ok := meta["has_next"].(bool); ok {
+			return !hasNext
+		}
+	}
+
+	return false
+}
+
+// listAll percorre todas as páginas da coleção
+func (c *APIClientV2) listAll(ctx context.Context, path string, filters url.Values) ([]map[string]interface{}, error) {
+	var all []map[string]interface{}
+
+	it := c.paginate(path, filters)
+	for {
+		items, err := it.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if items == nil {
+			return all, nil
+		}
+		all = append(all, items...)
+	}
+}
+
+// findFirst percorre as páginas até encontrar um item que satisfaça match
+func (c *APIClientV2) findFirst(ctx context.Context, path string, filters url.Values, match func(map[string]interface{}) bool) (map[string]interface{}, error) {
+	it := c.paginate(path, filters)
+	for {
+		items, err := it.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if items == nil {
+			return nil, nil
+		}
+		for _, item := range items {
+			if match(item) {
+				return item, nil
+			}
+		}
+	}
+}
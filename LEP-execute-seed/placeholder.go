@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bytes"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"unicode"
+)
+
+// placeholderSizes define as dimensões geradas por entidade, no tamanho que o frontend exibe
+var placeholderSizes = map[string]image.Point{
+	"menu":     {X: 1200, Y: 600},
+	"category": {X: 1200, Y: 600},
+	"product":  {X: 800, Y: 800},
+}
+
+// defaultPlaceholderPalette é usada quando o seed não define ThemeCustomization
+var defaultPlaceholderPalette = []string{"#8B0000", "#2E7D32", "#1565C0", "#6A1B9A", "#EF6C00", "#37474F"}
+
+// initialsStopWords são ignoradas ao extrair iniciais ("Risoto de Funghi" -> "RF")
+var initialsStopWords = map[string]bool{
+	"a": true, "o": true, "e": true, "de": true, "da": true, "do": true, "das": true, "dos": true,
+	"com": true, "em": true, "na": true, "no": true, "the": true, "of": true, "and": true,
+}
+
+// placeholderGlyphs é uma fonte bitmap 5x7 para A-Z, 0-9 e '?'
+var placeholderGlyphs = map[rune][7]string{
+	'A': {".###.", "#...#", "#...#", "#####", "#...#", "#...#", "#...#"},
+	'B': {"####.", "#...#", "#...#", "####.", "#...#", "#...#", "####."},
+	'C': {".###.", "#...#", "#....", "#....", "#....", "#...#", ".###."},
+	'D': {"####.", "#...#", "#...#", "#...#", "#...#", "#...#", "####."},
+	'E': {"#####", "#....", "#....", "####.", "#....", "#....", "#####"},
+	'F': {"#####", "#....", "#....", "####.", "#....", "#....", "#...."},
+	'G': {".###.", "#...#", "#....", "#.###", "#...#", "#...#", ".####"},
+	'H': {"#...#", "#...#", "#...#", "#####", "#...#", "#...#", "#...#"},
+	'I': {".###.", "..#..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'J': {"..###", "...#.", "...#.", "...#.", "...#.", "#..#.", ".##.."},
+	'K': {"#...#", "#..#.", "#.#..", "##...", "#.#..", "#..#.", "#...#"},
+	'L': {"#....", "#....", "#....", "#....", "#....", "#....", "#####"},
+	'M': {"#...#", "##.##", "#.#.#", "#.#.#", "#...#", "#...#", "#...#"},
+	'N': {"#...#", "#...#", "##..#", "#.#.#", "#..##", "#...#", "#...#"},
+	'O': {".###.", "#...#", "#...#", "#...#", "#...#", "#...#", ".###."},
+	'P': {"####.", "#...#", "#...#", "####.", "#....", "#....", "#...."},
+	'Q': {".###.", "#...#", "#...#", "#...#", "#.#.#", "#..#.", ".##.#"},
+	'R': {"####.", "#...#", "#...#", "####.", "#.#..", "#..#.", "#...#"},
+	'S': {".####", "#....", "#....", ".###.", "....#", "....#", "####."},
+	'T': {"#####", "..#..", "..#..", "..#..", "..#..", "..#..", "..#.."},
+	'U': {"#...#", "#...#", "#...#", "#...#", "#...#", "#...#", ".###."},
+	'V': {"#...#", "#...#", "#...#", "#...#", "#...#", ".#.#.", "..#.."},
+	'W': {"#...#", "#...#", "#...#", "#.#.#", "#.#.#", "#.#.#", ".#.#."},
+	'X': {"#...#", "#...#", ".#.#.", "..#..", ".#.#.", "#...#", "#...#"},
+	'Y': {"#...#", "#...#", ".#.#.", "..#..", "..#..", "..#..", "..#.."},
+	'Z': {"#####", "....#", "...#.", "..#..", ".#...", "#....", "#####"},
+	'0': {".###.", "#...#", "#..##", "#.#.#", "##..#", "#...#", ".###."},
+	'1': {"..#..", ".##..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'2': {".###.", "#...#", "....#", "...#.", "..#..", ".#...", "#####"},
+	'3': {"####.", "....#", "....#", ".###.", "....#", "....#", "####."},
+	'4': {"...#.", "..##.", ".#.#.", "#..#.", "#####", "...#.", "...#."},
+	'5': {"#####", "#....", "####.", "....#", "....#", "#...#", ".###."},
+	'6': {".###.", "#....", "#....", "####.", "#...#", "#...#", ".###."},
+	'7': {"#####", "....#", "...#.", "..#..", ".#...", ".#...", ".#..."},
+	'8': {".###.", "#...#", "#...#", ".###.", "#...#", "#...#", ".###."},
+	'9': {".###.", "#...#", "#...#", ".####", "....#", "....#", ".###."},
+	'?': {".###.", "#...#", "....#", "...#.", "..#..", ".....", "..#.."},
+}
+
+// accentFolding remove acentos das letras suportadas pela fonte bitmap
+var accentFolding = strings.NewReplacer(
+	"Á", "A", "À", "A", "Â", "A", "Ã", "A", "Ä", "A",
+	"É", "E", "È", "E", "Ê", "E", "Ë", "E",
+	"Í", "I", "Ì", "I", "Î", "I", "Ï", "I",
+	"Ó", "O", "Ò", "O", "Ô", "O", "Õ", "O", "Ö", "O",
+	"Ú", "U", "Ù", "U", "Û", "U", "Ü", "U",
+	"Ç", "C", "Ñ", "N",
+)
+
+// placeholderInitials extrai até duas iniciais do nome, ignorando preposições
+func placeholderInitials(name string) string {
+	var initials []rune
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		if initialsStopWords[strings.ToLower(word)] {
+			continue
+		}
+
+		first := []rune(accentFolding.Replace(strings.ToUpper(word)))[0]
+		if _, ok := placeholderGlyphs[first]; !ok {
+			continue
+		}
+
+		initials = append(initials, first)
+		if len(initials) == 2 {
+			break
+		}
+	}
+
+	if len(initials) == 0 {
+		return "?"
+	}
+	return string(initials)
+}
+
+// placeholderPalette monta a paleta a partir das cores do tema do projeto
+func placeholderPalette(theme ThemeCustomizationData) []color.RGBA {
+	var palette []color.RGBA
+	for _, hex := range []string{theme.PrimaryColor, theme.SecondaryColor, theme.AccentColor} {
+		if c, err := parseHexColor(hex); err == nil {
+			palette = append(palette, c)
+		}
+	}
+
+	if len(palette) == 0 {
+		for _, hex := range defaultPlaceholderPalette {
+			c, _ := parseHexColor(hex)
+			palette = append(palette, c)
+		}
+	}
+
+	return palette
+}
+
+// GeneratePlaceholderPNG desenha as iniciais do nome sobre uma cor da paleta.
+// A cor é escolhida pelo hash do nome, então o mesmo item gera sempre a mesma imagem
+// (e o ledger de imagens não o reenvia em re-execuções).
+func GeneratePlaceholderPNG(name string, size image.Point, palette []color.RGBA) ([]byte, error) {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	background := palette[h.Sum32()%uint32(len(palette))]
+
+	// Texto branco ou quase preto, o que tiver mais contraste com o fundo
+	foreground := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	if relativeLuminance(background) > 0.179 {
+		foreground = color.RGBA{R: 33, G: 33, B: 33, A: 255}
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, size.X, size.Y))
+	for y := 0; y < size.Y; y++ {
+		for x := 0; x < size.X; x++ {
+			img.SetRGBA(x, y, background)
+		}
+	}
+
+	initials := []rune(placeholderInitials(name))
+
+	// Cada glifo ocupa 5x7 células, com 1 célula de espaço entre glifos;
+	// a altura do texto fica em ~40% da menor dimensão da imagem
+	cols := len(initials)*6 - 1
+	minSide := size.X
+	if size.Y < minSide {
+		minSide = size.Y
+	}
+	scale := minSide * 2 / 5 / 7
+	if maxScale := size.X * 4 / 5 / cols; scale > maxScale {
+		scale = maxScale
+	}
+	if scale < 1 {
+		scale = 1
+	}
+
+	originX := (size.X - cols*scale) / 2
+	originY := (size.Y - 7*scale) / 2
+
+	for i, r := range initials {
+		glyph, ok := placeholderGlyphs[r]
+		if !ok {
+			continue
+		}
+		for row, line := range glyph {
+			for col, cell := range line {
+				if cell != '#' {
+					continue
+				}
+				x0 := originX + (i*6+col)*scale
+				y0 := originY + row*scale
+				for y := y0; y < y0+scale; y++ {
+					for x := x0; x < x0+scale; x++ {
+						img.SetRGBA(x, y, foreground)
+					}
+				}
+			}
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// pendingProduct é um produto do seed que ainda não existe no backend
+type pendingProduct struct {
+	idx     int // índice no seed (usado por ProductTags)
+	prod    ProductData
+	payload map[string]interface{}
+	isWine  bool
+}
+
+// seedProducts cria os produtos do seed (Passo 8) e retorna idx -> UUID.
+// Produtos novos são enviados em lotes pelo endpoint bulk quando seed.batch_size > 1;
+// qualquer item que o bulk rejeitar (ou não confirmar) é criado individualmente.
+func (s *SeedServiceV2) seedProducts(ctx context.Context, menuIDs, categoryIDs, subcategoryIDs map[int]string) map[int]string {
+	productIDs := make(map[int]string)
+	var pending []pendingProduct
+
+	for idx, prod := range s.seedData.Products {
+		if s.interrupted(ctx) {
+			break
+		}
+
+		// Verificar se produto já existe
+		if match := s.matchEntity(ctx, productEntity(prod)); match.id != uuid.Nil {
+			productIDs[idx] = match.id.String()
+			if !match.renamed {
+				s.logger.Info("Produto %s já existe", prod.Name)
+				s.state.skipped++
+			}
+			continue
+		}
+
+		var menuID, catID, subcatID *string
+
+		// Obter menu_id
+		if prod.MenuIDRef >= 0 && prod.MenuIDRef < len(s.seedData.Menus) {
+			if id, ok := menuIDs[prod.MenuIDRef]; ok {
+				menuID = &id
+			}
+		}
+
+		if prod.CategoryIDRef >= 0 && prod.CategoryIDRef < len(s.seedData.Categories) {
+			if id, ok := categoryIDs[prod.CategoryIDRef]; ok {
+				catID = &id
+			}
+		}
+
+		if prod.SubcategoryIDRef >= 0 && prod.SubcategoryIDRef < len(s.seedData.Subcategories) {
+			if id, ok := subcategoryIDs[prod.SubcategoryIDRef]; ok {
+				subcatID = &id
+			}
+		}
+
+		pending = append(pending, pendingProduct{
+			idx:     idx,
+			prod:    prod,
+			payload: BuildProductPayload(prod, menuID, catID, subcatID),
+			isWine:  prod.HasWineAttributes(),
+		})
+	}
+
+	if batchSize := s.config.Seed.BatchSize; batchSize > 1 && len(pending) > 1 {
+		pending = s.createProductsInBatches(ctx, pending, batchSize, productIDs)
+	}
+
+	for _, p := range pending {
+		if s.interrupted(ctx) {
+			break
+		}
+
+		id, err := s.client.CreateProductFromPayload(ctx, p.payload)
+		s.recordProductResult(p, id, err, productIDs)
+	}
+
+	return productIDs
+}
+
+// createProductsInBatches envia os produtos pelo endpoint bulk e retorna os que precisam de criação individual
+func (s *SeedServiceV2) createProductsInBatches(ctx context.Context, pending []pendingProduct, batchSize int, productIDs map[int]string) []pendingProduct {
+	var fallback []pendingProduct
+	totalBatches := (len(pending) + batchSize - 1) / batchSize
+
+	for start := 0; start < len(pending); start += batchSize {
+		if s.interrupted(ctx) {
+			break
+		}
+
+		end := start + batchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+		batch := pending[start:end]
+		batchNum := start/batchSize + 1
+
+		payloads := make([]map[string]interface{}, len(batch))
+		for i, p := range batch {
+			payloads[i] = p.payload
+		}
+
+		results, err := s.client.CreateProductsBulk(ctx, payloads)
+		if errors.Is(err, ErrBulkUnsupported) {
+			s.logger.Warn("Endpoint /product/bulk indisponível, criando produtos individualmente")
+			return append(fallback, pending[start:]...)
+		}
+		if err != nil {
+			// O lote pode ter sido aplicado parcialmente: reverificar antes de criar individualmente
+			s.logger.Warn("Lote %d/%d falhou (%v), reverificando %d produtos", batchNum, totalBatches, err, len(batch))
+			fallback = append(fallback, s.recheckProducts(ctx, batch, productIDs)...)
+			continue
+		}
+
+		created := 0
+		var unconfirmed []pendingProduct
+		for i, r := range results {
+			switch {
+			case errors.Is(r.Err, ErrBulkUnconfirmed):
+				unconfirmed = append(unconfirmed, batch[i])
+			case r.Err != nil:
+				s.logger.Debug("Bulk rejeitou produto %s: %v", batch[i].prod.Name, r.Err)
+				fallback = append(fallback, batch[i])
+			default:
+				s.recordProductResult(batch[i], r.ID, nil, productIDs)
+				created++
+			}
+		}
+		s.logger.Info("Lote %d/%d: %d criados, %d para criação individual", batchNum, totalBatches, created, len(batch)-created)
+
+		// Sem confirmação o item pode ter sido criado (resposta em formato desconhecido)
+		if len(unconfirmed) > 0 {
+			s.logger.Warn("Lote %d/%d: %d produtos sem confirmação, reverificando", batchNum, totalBatches, len(unconfirmed))
+			fallback = append(fallback, s.recheckProducts(ctx, unconfirmed, productIDs)...)
+		}
+	}
+
+	return fallback
+}
+
+// recheckProducts devolve apenas os produtos do lote que de fato não existem no backend
+func (s *SeedServiceV2) recheckProducts(ctx context.Context, batch []pendingProduct, productIDs map[int]string) []pendingProduct {
+	// O lote pode ter criado produtos sem resposta 2xx, que o cache de /product não conhece
+	s.client.cache.invalidate(s.client.cacheKey("/product"))
+
+	var missing []pendingProduct
+	for _, p := range batch {
+		existingID, err := s.client.GetProductByName(ctx, p.prod.Name)
+		if err == nil && existingID != uuid.Nil {
+			s.recordProductResult(p, existingID, nil, productIDs)
+			continue
+		}
+		missing = append(missing, p)
+	}
+	return missing
+}
+
+// productEntity identifica o produto para matchEntity
+func productEntity(prod ProductData) seedEntity {
+	return seedEntity{kind: "product", path: "/product", keyField: "name", key: prod.Name, externalID: prod.ExternalID}
+}
+
+// recordProductResult registra o resultado da criação de um produto no estado do seed
+func (s *SeedServiceV2) recordProductResult(p pendingProduct, id uuid.UUID, err error, productIDs map[int]string) {
+	if err != nil {
+		s.logger.With(Fields{"entity": "product"}).Error("Erro ao criar produto %s: %v", p.prod.Name, err)
+		s.state.failed++
+		s.state.errors = append(s.state.errors, newSeedError("product", p.prod.Name, err))
+		return
+	}
+
+	productIDs[p.idx] = id.String()
+	s.rememberExternalID(productEntity(p.prod), id)
+	if p.isWine {
+		s.logger.Info("Produto criado: %s (%s) - %s %s", p.prod.Name, p.prod.Type, p.prod.Country, p.prod.Vintage)
+	} else {
+		s.logger.Info("Produto criado: %s (%s)", p.prod.Name, p.prod.Type)
+	}
+	s.state.created++
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// UpdateOrganization aplica o perfil da organização (PUT /organization/{id}), com apenas os campos preenchidos.
+// O PUT substitui a organização inteira, então o perfil é mesclado à organização atual.
+func (c *APIClientV2) UpdateOrganization(ctx context.Context, orgID string, org OrgData) error {
+	resp, status, err := c.doRequest(ctx, "GET", "/organization/"+orgID, nil)
+	if err != nil {
+		return err
+	}
+	if status != 200 {
+		return newAPIError(status, resp)
+	}
+
+	current := resp
+	if data, ok := resp["data"].(map[string]interface{}); ok {
+		current = data
+	}
+	if id, _ := current["id"].(string); id != orgID {
+		return fmt.Errorf("GET /organization/%s não retornou a organização", orgID)
+	}
+
+	profile := make(map[string]interface{})
+	fields := map[string]string{
+		"email":       org.Email,
+		"phone":       org.Phone,
+		"address":     org.Address,
+		"website":     org.Website,
+		"description": org.Description,
+	}
+	for key, value := range fields {
+		if value != "" {
+			profile[key] = value
+		}
+	}
+	if org.Active {
+		profile["active"] = true
+	}
+
+	resp, status, err = c.doRequest(ctx, "PUT", "/organization/"+orgID, mergeFields(current, profile))
+	if err != nil {
+		return err
+	}
+
+	if status != 200 && status != 201 {
+		return newAPIError(status, resp)
+	}
+
+	return nil
+}
+
+// GetProjectByName busca um projeto da organização pelo nome
+func (c *APIClientV2) GetProjectByName(ctx context.Context, name string) (uuid.UUID, error) {
+	return c.findByField(ctx, "/project", "name", name)
+}
+
+// CreateProject cria um projeto (unidade) na organização atual
+func (c *APIClientV2) CreateProject(ctx context.Context, name, description string) (uuid.UUID, error) {
+	payload := map[string]interface{}{
+		"name":   name,
+		"active": true,
+	}
+
+	if description != "" {
+		payload["description"] = description
+	}
+
+	resp, status, err := c.doCreate(ctx, "/project", payload, func() (uuid.UUID, error) { return c.GetProjectByName(ctx, name) })
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if status == 409 {
+		return uuid.Nil, fmt.Errorf("already_exists")
+	}
+
+	if status != 200 && status != 201 {
+		return uuid.Nil, newAPIError(status, resp)
+	}
+
+	return extractIDFromResponse(resp)
+}
+
+// hasProfile indica se o seed declara algum campo de perfil além do nome
+func (o OrgData) hasProfile() bool {
+	return o.Email != "" || o.Phone != "" || o.Address != "" || o.Website != "" || o.Description != ""
+}
+
+// applyOrganizationProfile envia email, telefone, endereço, site e descrição da organização (Passo 2b)
+func (s *SeedServiceV2) applyOrganizationProfile(ctx context.Context, orgID string) {
+	org := s.seedData.Organization
+	if !org.hasProfile() {
+		s.logger.Info("Nenhum perfil de organização definido no seed")
+		return
+	}
+
+	if err := s.client.UpdateOrganization(ctx, orgID, org); err != nil {
+		s.logger.Error("Erro ao atualizar perfil da organização: %v", err)
+		s.state.failed++
+		s.state.errors = append(s.state.errors, newSeedError("org", s.config.Auth.OrganizationName, err))
+		return
+	}
+
+	s.logger.Info("Perfil da organização atualizado")
+	s.state.updated++
+}
+
+// seedProjects cria cada projeto declarado em "projects" e executa suas seções nele.
+// Ao final o client volta ao projeto padrão (defaultProjID).
+func (s *SeedServiceV2) seedProjects(runCtx context.Context, orgID, defaultProjID string) {
+	defer s.client.SetHeaders(s.client.token, orgID, defaultProjID)
+	defer func() { s.state.project = "" }()
+
+	for i := range s.seedData.Projects {
+		project := &s.seedData.Projects[i]
+		s.state.project = project.Name
+		ctx := s.beginStep(runCtx, fmt.Sprintf("Projeto %d/%d: %s", i+1, len(s.seedData.Projects), project.Name))
+		if s.interrupted(ctx) {
+			break
+		}
+
+		// Projetos são listados no contexto do projeto padrão
+		s.client.SetHeaders(s.client.token, orgID, defaultProjID)
+
+		ref := seedEntity{kind: "project", path: "/project", keyField: "name", key: project.Name, externalID: project.ExternalID}
+		match := s.matchEntity(ctx, ref)
+		projID := match.id
+		if projID != uuid.Nil {
+			if !match.renamed {
+				s.logger.Info("Projeto %s já existe", project.Name)
+				s.state.skipped++
+			}
+		} else {
+			var err error
+			projID, err = s.client.CreateProject(ctx, project.Name, project.Description)
+			if err != nil {
+				s.logger.Error("Erro ao criar projeto %s: %v", project.Name, err)
+				s.state.failed++
+				s.state.errors = append(s.state.errors, newSeedError("project", project.Name, err))
+				continue
+			}
+			s.rememberExternalID(ref, projID)
+			s.logger.Info("Projeto criado: %s", project.Name)
+			s.state.created++
+		}
+
+		s.client.SetHeaders(s.client.token, orgID, projID.String())
+
+		child := *s
+		child.seedData = &project.SeedData
+		child.seedSections(runCtx)
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestUpdateOrganizationMergesProfile(t *testing.T) {
+	const orgID = "0a000000-0000-0000-0000-000000000001"
+	profile := OrgData{Name: "Fattoria", Phone: "+55 11 99999-9999", Website: "https://fattoria.example"}
+
+	tests := []struct {
+		name    string
+		status  int
+		current string
+		wantPut map[string]interface{} // nil: nenhum PUT enviado
+		wantErr bool
+	}{
+		{
+			name:    "perfil mesclado à organização atual",
+			status:  200,
+			current: `{"data": {"id": "` + orgID + `", "name": "Fattoria", "phone": "antigo", "plan": "pro", "active": true}}`,
+			wantPut: map[string]interface{}{
+				"id": orgID, "name": "Fattoria", "phone": "+55 11 99999-9999", "plan": "pro", "active": true,
+				"website": "https://fattoria.example",
+			},
+		},
+		{
+			name:    "organização na raiz da resposta",
+			status:  200,
+			current: `{"id": "` + orgID + `", "name": "Fattoria"}`,
+			wantPut: map[string]interface{}{
+				"id": orgID, "name": "Fattoria", "phone": "+55 11 99999-9999", "website": "https://fattoria.example",
+			},
+		},
+		{
+			name:    "GET falhou",
+			status:  404,
+			current: `{"message": "not found"}`,
+			wantErr: true,
+		},
+		{
+			name:    "resposta sem a organização",
+			status:  200,
+			current: `{"data": {"name": "Outra"}}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var put map[string]interface{}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPut {
+					body, _ := io.ReadAll(r.Body)
+					json.Unmarshal(body, &put)
+					w.Write([]byte(`{}`))
+					return
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.current))
+			}))
+			defer srv.Close()
+
+			cfg := &Config{}
+			cfg.Retry.MaxAttempts = 1
+			err := NewAPIClientV2(srv.URL, NewLogger(false), cfg).UpdateOrganization(context.Background(), orgID, profile)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UpdateOrganization() erro = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(put, tt.wantPut) {
+				t.Errorf("PUT = %v, want %v", put, tt.wantPut)
+			}
+		})
+	}
+}
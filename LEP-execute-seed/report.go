@@ -0,0 +1,330 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Códigos de saída do seeder
+const (
+	exitOK         = 0
+	exitPartial    = 1   // o seed rodou, mas algum item falhou
+	exitUsage      = 2   // subcomando desconhecido
+	exitValidation = 3   // configuração ou arquivo de seed inválido
+	exitAuth       = 4   // não foi possível criar a organização ou autenticar
+	exitInterrupt  = 130 // interrompido por Ctrl-C ou SIGTERM
+)
+
+// Status de cada arquivo no relatório
+const (
+	fileOK              = "ok"
+	filePartial         = "partial"
+	fileValidationError = "validation_error"
+	fileAuthError       = "auth_error"
+	fileInterrupted     = "interrupted"
+)
+
+// RunCounts são os contadores de uma execução, arquivo ou passo
+type RunCounts struct {
+	Created  int `json:"created"`
+	Skipped  int `json:"skipped"`
+	Updated  int `json:"updated"`
+	Failed   int `json:"failed"`
+	Warnings int `json:"warnings"`
+}
+
+func (c RunCounts) sub(base RunCounts) RunCounts {
+	return RunCounts{
+		Created:  c.Created - base.Created,
+		Skipped:  c.Skipped - base.Skipped,
+		Updated:  c.Updated - base.Updated,
+		Failed:   c.Failed - base.Failed,
+		Warnings: c.Warnings - base.Warnings,
+	}
+}
+
+func (c *RunCounts) add(other RunCounts) {
+	c.Created += other.Created
+	c.Skipped += other.Skipped
+	c.Updated += other.Updated
+	c.Failed += other.Failed
+	c.Warnings += other.Warnings
+}
+
+// StepReport registra contadores e duração de um passo do seed
+type StepReport struct {
+	Project    string    `json:"project,omitempty"`
+	Name       string    `json:"name"`
+	Counts     RunCounts `json:"counts"`
+	DurationMs int64     `json:"duration_ms"`
+
+	started     time.Time
+	base        RunCounts
+	baseErrors  int
+	baseWarns   int
+	done        bool
+	cancel      context.CancelFunc // libera o contexto do passo (step_timeout)
+	interrupted bool
+}
+
+// FileReport é o resultado de um arquivo de seed
+type FileReport struct {
+	File        string                                  `json:"file"`
+	Status      string                                  `json:"status"`
+	Counts      RunCounts                               `json:"counts"`
+	DurationMs  int64                                   `json:"duration_ms"`
+	Steps       []*StepReport                           `json:"steps,omitempty"`
+	Errors      []SeedError                             `json:"errors"`
+	Warnings    []SeedError                             `json:"warnings,omitempty"`
+	ResolvedIDs map[string]map[string]map[string]string `json:"resolved_ids,omitempty"` // projeto -> entidade -> nome -> ID
+}
+
+// RunReport é o relatório JSON gravado com -report
+type RunReport struct {
+	StartedAt  time.Time     `json:"started_at"`
+	FinishedAt time.Time     `json:"finished_at"`
+	DurationMs int64         `json:"duration_ms"`
+	ExitCode   int           `json:"exit_code"`
+	Totals     RunCounts     `json:"totals"`
+	Files      []*FileReport `json:"files"`
+}
+
+// newSeedError monta o erro do relatório; respostas do backend levam status e corpo
+func newSeedError(errType, item string, err error) SeedError {
+	seedErr := SeedError{Type: errType, Item: item, Message: err.Error()}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		seedErr.Status = apiErr.Status
+		seedErr.Body = apiErr.Body
+	}
+	return seedErr
+}
+
+func (st *SeedState) counts() RunCounts {
+	return RunCounts{Created: st.created, Skipped: st.skipped, Updated: st.updated, Failed: st.failed, Warnings: len(st.warnings)}
+}
+
+// beginStep encerra o passo anterior e começa a contar um novo
+func (st *SeedState) beginStep(name string, cancel context.CancelFunc) {
+	st.endStep()
+	st.steps = append(st.steps, &StepReport{
+		Project:    st.project,
+		Name:       name,
+		started:    time.Now(),
+		base:       st.counts(),
+		baseErrors: len(st.errors),
+		baseWarns:  len(st.warnings),
+		cancel:     cancel,
+	})
+}
+
+// endStep fecha o passo atual e marca os erros e avisos registrados nele
+func (st *SeedState) endStep() {
+	if len(st.steps) == 0 {
+		return
+	}
+	step := st.steps[len(st.steps)-1]
+	if step.done {
+		return
+	}
+
+	if step.cancel != nil {
+		step.cancel()
+	}
+	step.Counts = st.counts().sub(step.base)
+	step.DurationMs = time.Since(step.started).Milliseconds()
+	markStep(st.errors[step.baseErrors:], step)
+	markStep(st.warnings[step.baseWarns:], step)
+	step.done = true
+}
+
+func markStep(entries []SeedError, step *StepReport) {
+	for i := range entries {
+		if entries[i].Step == "" {
+			entries[i].Step = step.Name
+			entries[i].Project = step.Project
+		}
+	}
+}
+
+// beginStep exibe o cabeçalho do passo, abre sua contagem no relatório
+// e retorna o contexto do passo, limitado por server.step_timeout
+func (s *SeedServiceV2) beginStep(runCtx context.Context, title string) context.Context {
+	s.logger.SetStep(title)
+	s.logger.Section(title)
+
+	ctx, cancel := runCtx, context.CancelFunc(nil)
+	if timeout := time.Duration(s.config.Server.StepTimeout) * time.Second; timeout > 0 {
+		ctx, cancel = context.WithTimeout(runCtx, timeout)
+	}
+	s.state.beginStep(title, cancel)
+	return ctx
+}
+
+// interrupted indica se o passo foi cancelado (Ctrl-C, SIGTERM ou step_timeout) e os itens
+// restantes não devem ser enviados. O motivo é registrado uma vez: por passo no step_timeout,
+// por execução no cancelamento.
+func (s *SeedServiceV2) interrupted(ctx context.Context) bool {
+	err := ctx.Err()
+	if err == nil {
+		return false
+	}
+
+	step := s.state.steps[len(s.state.steps)-1]
+	if step.interrupted || s.state.cancelled {
+		return true
+	}
+
+	errType, msg := "interrupted", "execução interrompida"
+	if errors.Is(err, context.DeadlineExceeded) {
+		step.interrupted = true
+		errType, msg = "timeout", fmt.Sprintf("passo excedeu server.step_timeout (%ds)", s.config.Server.StepTimeout)
+	} else {
+		s.state.cancelled = true
+	}
+	s.logger.Warn("%s: %s, itens restantes não enviados", step.Name, msg)
+	s.state.failed++
+	s.state.errors = append(s.state.errors, SeedError{Type: errType, Item: step.Name, Message: msg})
+	return true
+}
+
+// recordResolvedIDs guarda no relatório o ID de cada entidade do seed criada ou encontrada
+func (s *SeedServiceV2) recordResolvedIDs(ids map[string]map[int]string) {
+	project := s.state.project
+	if project == "" {
+		project = defaultProjectAlias
+	}
+
+	if s.state.resolved == nil {
+		s.state.resolved = make(map[string]map[string]map[string]string)
+	}
+	byEntity, ok := s.state.resolved[project]
+	if !ok {
+		byEntity = make(map[string]map[string]string)
+		s.state.resolved[project] = byEntity
+	}
+
+	for entity, byIdx := range ids {
+		if len(byIdx) == 0 {
+			continue
+		}
+		if byEntity[entity] == nil {
+			byEntity[entity] = make(map[string]string, len(byIdx))
+		}
+		for idx, id := range byIdx {
+			byEntity[entity][s.seedKey(entity, idx)] = id
+		}
+	}
+}
+
+// seedKey retorna a chave natural da entidade do seed (nome, email, número da mesa, chave de confirmação)
+func (s *SeedServiceV2) seedKey(entity string, idx int) string {
+	d := s.seedData
+	switch entity {
+	case "menu":
+		return d.Menus[idx].Name
+	case "category":
+		return d.Categories[idx].Name
+	case "subcategory":
+		return d.Subcategories[idx].Name
+	case "environment":
+		return d.Environments[idx].Name
+	case "table":
+		return strconv.Itoa(d.Tables[idx].Number)
+	case "product":
+		return d.Products[idx].Name
+	case "user":
+		return d.Users[idx].Email
+	case "customer":
+		return d.Customers[idx].Email
+	case "tag":
+		return d.Tags[idx].Name
+	case "reservation":
+		if key := d.Reservations[idx].ConfirmationKey; key != "" {
+			return key
+		}
+	}
+	return fmt.Sprintf("%s[%d]", entity, idx)
+}
+
+// newFileReport monta o resultado do arquivo a partir do estado do serviço
+func newFileReport(file string, state *SeedState, duration time.Duration) *FileReport {
+	state.endStep()
+
+	report := &FileReport{
+		File:        file,
+		Status:      fileOK,
+		Counts:      state.counts(),
+		DurationMs:  duration.Milliseconds(),
+		Steps:       state.steps,
+		Errors:      state.errors,
+		Warnings:    state.warnings,
+		ResolvedIDs: state.resolved,
+	}
+	if report.Counts.Failed > 0 {
+		report.Status = filePartial
+	}
+	return report
+}
+
+// newInvalidFileReport registra um arquivo que não passou na validação
+func newInvalidFileReport(file, errType string, err error) *FileReport {
+	return &FileReport{
+		File:   file,
+		Status: fileValidationError,
+		Counts: RunCounts{Failed: 1},
+		Errors: []SeedError{newSeedError(errType, file, err)},
+	}
+}
+
+// exitCode escolhe o código de saída: falha de autenticação, depois interrupção,
+// depois validação, depois falhas parciais
+func (r *RunReport) exitCode() int {
+	code := exitOK
+	for _, f := range r.Files {
+		switch f.Status {
+		case fileAuthError:
+			return exitAuth
+		case fileInterrupted:
+			code = exitInterrupt
+		case fileValidationError:
+			if code != exitInterrupt {
+				code = exitValidation
+			}
+		case filePartial:
+			if code == exitOK {
+				code = exitPartial
+			}
+		}
+	}
+	return code
+}
+
+// finish fecha o relatório e calcula totais e código de saída
+func (r *RunReport) finish() {
+	r.FinishedAt = time.Now()
+	r.DurationMs = r.FinishedAt.Sub(r.StartedAt).Milliseconds()
+	r.Totals = RunCounts{}
+	for _, f := range r.Files {
+		r.Totals.add(f.Counts)
+	}
+	r.ExitCode = r.exitCode()
+}
+
+// Write grava o relatório em JSON
+func (r *RunReport) Write(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("erro ao serializar relatório: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("erro ao gravar relatório: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"time"
+
+	"lep-shared/retry"
+)
+
+// NewRetryPolicy cria política de retry a partir da configuração
+func NewRetryPolicy(config *Config) retry.Policy {
+	policy := retry.Policy{
+		MaxAttempts:    config.Retry.MaxAttempts,
+		InitialBackoff: time.Duration(config.Retry.InitialBackoffMs) * time.Millisecond,
+		MaxBackoff:     time.Duration(config.Retry.MaxBackoffMs) * time.Millisecond,
+	}
+
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	if policy.InitialBackoff <= 0 {
+		policy.InitialBackoff = 500 * time.Millisecond
+	}
+	if policy.MaxBackoff < policy.InitialBackoff {
+		policy.MaxBackoff = policy.InitialBackoff
+	}
+
+	return policy
+}
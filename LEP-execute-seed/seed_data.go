@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -28,6 +29,8 @@ type MenuData struct {
 	ApplicableDays    string `json:"applicable_days,omitempty"`
 	ApplicableDates   string `json:"applicable_dates,omitempty"`
 	IsManualOverride  bool   `json:"is_manual_override,omitempty"`
+	ImagePath         string `json:"image_path,omitempty"` // não suportado: ignorado com aviso
+	ExternalID        string `json:"external_id,omitempty"`
 }
 
 type CategoryData struct {
@@ -36,14 +39,26 @@ type CategoryData struct {
 	MenuIDRef   int    `json:"menu_id_ref"`
 	Active      bool   `json:"active"`
 	Order       int    `json:"order"`
+	ImagePath   string `json:"image_path,omitempty"` // relativo ao arquivo de seed
+	ExternalID  string `json:"external_id,omitempty"`
 }
 
 type SubcategoryData struct {
-	Name          string `json:"name"`
-	Description   string `json:"description"`
-	CategoryIDRef int    `json:"category_id_ref"`
-	Active        bool   `json:"active"`
-	Order         int    `json:"order"`
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	CategoryIDRef  int    `json:"category_id_ref"`
+	CategoryIDRefs []int  `json:"category_id_refs,omitempty"` // N:M; quando presente substitui category_id_ref
+	Active         bool   `json:"active"`
+	Order          int    `json:"order"`
+	ExternalID     string `json:"external_id,omitempty"`
+}
+
+// CategoryRefs retorna os índices das categorias da subcategoria
+func (s SubcategoryData) CategoryRefs() []int {
+	if len(s.CategoryIDRefs) > 0 {
+		return s.CategoryIDRefs
+	}
+	return []int{s.CategoryIDRef}
 }
 
 type EnvironmentData struct {
@@ -51,6 +66,7 @@ type EnvironmentData struct {
 	Description string `json:"description"`
 	Capacity    int    `json:"capacity"`
 	Active      bool   `json:"active"`
+	ExternalID  string `json:"external_id,omitempty"`
 }
 
 type TableData struct {
@@ -59,6 +75,7 @@ type TableData struct {
 	Location         string `json:"location"`
 	Status           string `json:"status"`
 	EnvironmentIDRef int    `json:"environment_id_ref"`
+	ExternalID       string `json:"external_id,omitempty"`
 }
 
 type ProductData struct {
@@ -73,7 +90,7 @@ type ProductData struct {
 	MenuIDRef        int     `json:"menu_id_ref"`
 	CategoryIDRef    int     `json:"category_id_ref"`
 	SubcategoryIDRef int     `json:"subcategory_id_ref"`
-	Active           bool    `json:"active"`
+	Active           *bool   `json:"active,omitempty"` // omitido = ativo
 	Order            int     `json:"order"`
 	PrepTimeMinutes  int     `json:"prep_time_minutes"`
 	Vintage          string  `json:"vintage"`
@@ -83,6 +100,14 @@ type ProductData struct {
 	WineType         string  `json:"wine_type"`
 	Volume           int     `json:"volume"`
 	AlcoholContent   float64 `json:"alcohol_content"`
+	ImagePath        string  `json:"image_path,omitempty"` // relativo ao arquivo de seed
+	ExternalID       string  `json:"external_id,omitempty"`
+}
+
+// HasWineAttributes indica se o produto declara atributos de vinho, independente do type
+func (p ProductData) HasWineAttributes() bool {
+	return p.Vintage != "" || p.Country != "" || p.Region != "" || p.Winery != "" ||
+		p.WineType != "" || p.Volume > 0 || p.AlcoholContent > 0
 }
 
 type SettingsData struct {
@@ -101,56 +126,96 @@ type SettingsData struct {
 }
 
 type NotificationTemplateData struct {
-	Name    string `json:"name"`
-	Channel string `json:"channel"`
-	Subject string `json:"subject"`
-	Body    string `json:"body"`
-	Active  bool   `json:"active"`
+	Name       string `json:"name"`
+	Channel    string `json:"channel"`
+	Subject    string `json:"subject"`
+	Body       string `json:"body"`
+	Active     bool   `json:"active"`
+	ExternalID string `json:"external_id,omitempty"`
 }
 
 type ThemeCustomizationData struct {
-	PrimaryColor           string  `json:"primary_color"`
-	SecondaryColor         string  `json:"secondary_color"`
-	BackgroundColor        string  `json:"background_color"`
-	CardBackgroundColor    string  `json:"card_background_color"`
-	TextColor              string  `json:"text_color"`
-	TextSecondaryColor     string  `json:"text_secondary_color"`
-	AccentColor            string  `json:"accent_color"`
-	SuccessColor           string  `json:"success_color,omitempty"`
-	ErrorColor             string  `json:"error_color,omitempty"`
-	WarningColor           string  `json:"warning_color,omitempty"`
-	InfoColor              string  `json:"info_color,omitempty"`
-	DisabledOpacity        float64 `json:"disabled_opacity,omitempty"`
-	ShadowIntensity        float64 `json:"shadow_intensity,omitempty"`
-	IsActive               bool    `json:"is_active"`
+	PrimaryColor        string `json:"primary_color"`
+	SecondaryColor      string `json:"secondary_color"`
+	BackgroundColor     string `json:"background_color"`
+	CardBackgroundColor string `json:"card_background_color"`
+	TextColor           string `json:"text_color"`
+	TextSecondaryColor  string `json:"text_secondary_color"`
+	AccentColor         string `json:"accent_color"`
+	SuccessColor        string `json:"success_color,omitempty"`
+	ErrorColor          string `json:"error_color,omitempty"`
+	WarningColor        string `json:"warning_color,omitempty"`
+	InfoColor           string `json:"info_color,omitempty"`
+
+	// Paleta dark: cada cor é opcional; quando omitida é derivada da cor light (ver theme.go)
+	PrimaryColorDark        string `json:"primary_color_dark,omitempty"`
+	SecondaryColorDark      string `json:"secondary_color_dark,omitempty"`
+	BackgroundColorDark     string `json:"background_color_dark,omitempty"`
+	CardBackgroundColorDark string `json:"card_background_color_dark,omitempty"`
+	TextColorDark           string `json:"text_color_dark,omitempty"`
+	TextSecondaryColorDark  string `json:"text_secondary_color_dark,omitempty"`
+	AccentColorDark         string `json:"accent_color_dark,omitempty"`
+	SuccessColorDark        string `json:"success_color_dark,omitempty"`
+	ErrorColorDark          string `json:"error_color_dark,omitempty"`
+	WarningColorDark        string `json:"warning_color_dark,omitempty"`
+	InfoColorDark           string `json:"info_color_dark,omitempty"`
+
+	DisabledOpacity float64 `json:"disabled_opacity,omitempty"`
+	ShadowIntensity float64 `json:"shadow_intensity,omitempty"`
+	IsActive        bool    `json:"is_active"`
+}
+
+// DisplaySettingsData configura a exibição do cardápio (/project/settings/display).
+// Campos omitidos não são enviados e mantêm o valor atual do projeto.
+type DisplaySettingsData struct {
+	ShowPrices       *bool `json:"show_prices,omitempty"`
+	ShowDescriptions *bool `json:"show_descriptions,omitempty"`
+	ShowImages       *bool `json:"show_images,omitempty"`
+	ItemPerPage      *int  `json:"item_per_page,omitempty"`
+}
+
+// MenuOverrideData fixa um menu como override manual da seleção automática
+type MenuOverrideData struct {
+	MenuIDRef int `json:"menu_id_ref"`
 }
 
 type UserData struct {
-	Name        string   `json:"name"`
-	Email       string   `json:"email"`
-	Password    string   `json:"password"`
-	Role        string   `json:"role"` // admin, manager, waiter, kitchen
-	Permissions []string `json:"permissions,omitempty"`
-	Active      bool     `json:"active"`
+	Name        string               `json:"name"`
+	Email       string               `json:"email"`
+	Password    string               `json:"password"`
+	Role        string               `json:"role"` // admin, manager, waiter, kitchen
+	Permissions []string             `json:"permissions,omitempty"`
+	Active      bool                 `json:"active"`
+	Memberships []UserMembershipData `json:"memberships,omitempty"`
+	ExternalID  string               `json:"external_id,omitempty"`
+}
+
+// UserMembershipData dá ao usuário acesso a uma organização ou projeto com um papel
+type UserMembershipData struct {
+	Scope string `json:"scope"`          // organization ou project
+	Name  string `json:"name,omitempty"` // vazio = organização/projeto do seed atual
+	Role  string `json:"role"`
 }
 
 type CustomerData struct {
-	Name      string `json:"name"`
-	Email     string `json:"email"`
-	Phone     string `json:"phone"`
-	BirthDate string `json:"birth_date,omitempty"` // YYYY-MM-DD
-	Notes     string `json:"notes,omitempty"`
-	Active    bool   `json:"active"`
+	Name       string `json:"name"`
+	Email      string `json:"email"`
+	Phone      string `json:"phone"`
+	BirthDate  string `json:"birth_date,omitempty"` // YYYY-MM-DD ou data relativa ("today-30y+3d")
+	Notes      string `json:"notes,omitempty"`
+	Active     bool   `json:"active"`
+	ExternalID string `json:"external_id,omitempty"`
 }
 
 type ReservationData struct {
 	CustomerIDRef   int    `json:"customer_id_ref"`
 	TableIDRef      int    `json:"table_id_ref"`
-	DateTime        string `json:"datetime"` // ISO8601
+	DateTime        string `json:"datetime"` // ISO8601 ou data relativa ("today+2d 19:30")
 	PartySize       int    `json:"party_size"`
 	Notes           string `json:"notes,omitempty"`
 	Status          string `json:"status"` // confirmed, cancelled, completed, no_show
 	ConfirmationKey string `json:"confirmation_key,omitempty"`
+	ExternalID      string `json:"external_id,omitempty"`
 }
 
 type OrderItemData struct {
@@ -184,6 +249,7 @@ type TagData struct {
 	Description string `json:"description,omitempty"`
 	EntityType  string `json:"entity_type,omitempty"` // product, menu, etc
 	Active      bool   `json:"active"`
+	ExternalID  string `json:"external_id,omitempty"`
 }
 
 type ProductTagData struct {
@@ -191,6 +257,13 @@ type ProductTagData struct {
 	TagIDRef     int `json:"tag_id_ref"`
 }
 
+// TagAssignmentData vincula uma tag a uma entidade do seed (ex.: clientes "VIP")
+type TagAssignmentData struct {
+	TagIDRef    int    `json:"tag_id_ref"`
+	EntityType  string `json:"entity_type"`   // product, menu, customer, table, reservation
+	EntityIDRef int    `json:"entity_id_ref"` // índice no array da entidade
+}
+
 type NotificationConfigData struct {
 	EventType  string   `json:"event_type"` // reservation_created, order_ready, etc
 	Enabled    bool     `json:"enabled"`
@@ -208,9 +281,18 @@ type LeadData struct {
 	Active    bool   `json:"active"`
 }
 
+// ProjectSeedData é um projeto (unidade) da organização com suas próprias seções de seed
+type ProjectSeedData struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	ExternalID  string `json:"external_id,omitempty"`
+	SeedData
+}
+
 type SeedData struct {
 	// Organization & Projects
-	Organization OrgData `json:"organization"`
+	Organization OrgData           `json:"organization"`
+	Projects     []ProjectSeedData `json:"projects,omitempty"`
 
 	// Menu System
 	Menus         []MenuData        `json:"menus"`
@@ -237,12 +319,19 @@ type SeedData struct {
 
 	// Relationships
 	ProductTags         []ProductTagData         `json:"product_tags,omitempty"`
+	TagAssignments      []TagAssignmentData      `json:"tag_assignments,omitempty"`
 	NotificationConfigs []NotificationConfigData `json:"notification_configs,omitempty"`
 
 	// Configuration
 	Settings              SettingsData              `json:"settings,omitempty"`
 	NotificationTemplates []NotificationTemplateData `json:"notification_templates,omitempty"`
 	ThemeCustomization    ThemeCustomizationData    `json:"theme_customization,omitempty"`
+
+	// Seções com reset: ausente mantém o estado do projeto, null restaura o padrão
+	DisplaySettings      *DisplaySettingsData `json:"display_settings,omitempty"`
+	MenuOverride         *MenuOverrideData    `json:"menu_override,omitempty"`
+	ResetDisplaySettings bool                 `json:"-"`
+	ResetMenuOverride    bool                 `json:"-"`
 }
 
 func LoadSeedDataFromFile(filePath string) (*SeedData, error) {
@@ -255,10 +344,45 @@ func LoadSeedDataFromFile(filePath string) (*SeedData, error) {
 	if err := json.Unmarshal(data, &seedData); err != nil {
 		return nil, fmt.Errorf("erro ao parsear JSON: %w", err)
 	}
+	if err := seedData.detectNullSections(data); err != nil {
+		return nil, err
+	}
 
 	return &seedData, nil
 }
 
+// detectNullSections marca as seções declaradas explicitamente como null,
+// que o json.Unmarshal não distingue de seções ausentes
+func (s *SeedData) detectNullSections(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("erro ao parsear JSON: %w", err)
+	}
+
+	isNull := func(key string) bool {
+		value, ok := raw[key]
+		return ok && string(bytes.TrimSpace(value)) == "null"
+	}
+
+	s.ResetDisplaySettings = isNull("display_settings")
+	s.ResetMenuOverride = isNull("menu_override")
+
+	if len(s.Projects) == 0 {
+		return nil
+	}
+
+	var projects []json.RawMessage
+	if err := json.Unmarshal(raw["projects"], &projects); err != nil {
+		return fmt.Errorf("erro ao parsear JSON: %w", err)
+	}
+	for i := range s.Projects {
+		if err := s.Projects[i].detectNullSections(projects[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *SeedData) ValidateSeedData() error {
 	if s.Organization.Name == "" {
 		return fmt.Errorf("organização deve ter um nome")
@@ -267,5 +391,49 @@ func (s *SeedData) ValidateSeedData() error {
 		return fmt.Errorf("deve haver pelo menos um menu")
 	}
 
+	return s.ValidateSections()
+}
+
+// ValidateSections valida as seções opcionais do seed e de cada projeto; roda para todo arquivo antes de enviar qualquer dado
+func (s *SeedData) ValidateSections() error {
+	if err := s.validateProjectSections(); err != nil {
+		return err
+	}
+
+	names := make(map[string]bool, len(s.Projects))
+	for i, p := range s.Projects {
+		if p.Name == "" {
+			return fmt.Errorf("projects[%d]: nome é obrigatório", i)
+		}
+		if names[p.Name] {
+			return fmt.Errorf("projects[%d]: projeto %q declarado mais de uma vez", i, p.Name)
+		}
+		names[p.Name] = true
+
+		if len(p.Projects) > 0 {
+			return fmt.Errorf("projects[%d]: projetos não podem ter sub-projetos", i)
+		}
+		if err := p.validateProjectSections(); err != nil {
+			return fmt.Errorf("projects[%d] (%s): %w", i, p.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *SeedData) validateProjectSections() error {
+	if err := s.ThemeCustomization.Validate(); err != nil {
+		return err
+	}
+	if err := s.ValidateTagAssignments(); err != nil {
+		return err
+	}
+	if err := s.ValidateMemberships(); err != nil {
+		return err
+	}
+	if err := s.ValidateExternalIDs(); err != nil {
+		return err
+	}
+
 	return nil
 }
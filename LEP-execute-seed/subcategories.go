@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// reconcileSubcategoryLinks garante que a subcategoria esteja vinculada exatamente às categorias do seed:
+// vínculos ausentes são criados e, com seed.prune_links, vínculos extras são removidos.
+// Sem a listagem dos vínculos atuais nada é desvinculado.
+func (s *SeedServiceV2) reconcileSubcategoryLinks(ctx context.Context, name, subcatID string, catIDs []string) {
+	current, err := s.client.GetSubcategoryCategoryIDs(ctx, subcatID)
+	listed := err == nil
+	if !listed {
+		// Sem a listagem não dá para comparar; o POST de vínculo ignora relações já existentes
+		if s.config.Seed.PruneLinks {
+			err = fmt.Errorf("não foi possível listar as categorias vinculadas, nenhum vínculo removido: %w", err)
+			s.logger.With(Fields{"entity": "subcategory_link"}).Error("Subcategoria %s: %v", name, err)
+			s.state.failed++
+			s.state.errors = append(s.state.errors, newSeedError("subcategory_link", name, err))
+		} else {
+			s.logger.Debug("Não foi possível listar categorias da subcategoria %s: %v", name, err)
+		}
+		current = nil
+	}
+
+	linked := make(map[string]bool, len(current))
+	for _, id := range current {
+		linked[id] = true
+	}
+
+	wanted := make(map[string]bool, len(catIDs))
+	for _, catID := range catIDs {
+		wanted[catID] = true
+		if linked[catID] {
+			continue
+		}
+
+		if err := s.client.AddCategoryToSubcategory(ctx, subcatID, catID); err != nil {
+			s.recordLinkError(name, "vincular", catID, err)
+			continue
+		}
+		s.logger.Info("Subcategoria %s vinculada à categoria %s", name, catID)
+	}
+
+	if !s.config.Seed.PruneLinks || !listed {
+		return
+	}
+
+	for _, catID := range current {
+		if wanted[catID] {
+			continue
+		}
+
+		if err := s.client.RemoveCategoryFromSubcategory(ctx, subcatID, catID); err != nil {
+			s.recordLinkError(name, "desvincular", catID, err)
+			continue
+		}
+		s.logger.Info("Subcategoria %s desvinculada da categoria %s (fora do seed)", name, catID)
+	}
+}
+
+func (s *SeedServiceV2) recordLinkError(name, action, catID string, err error) {
+	s.logger.With(Fields{"entity": "subcategory_link"}).Error("Erro ao %s subcategoria %s da categoria %s: %v", action, name, catID, err)
+	s.state.failed++
+	s.state.errors = append(s.state.errors, newSeedError("subcategory_link", name, err))
+}
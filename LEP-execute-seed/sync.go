@@ -0,0 +1,341 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// syncEntity descreve uma coleção do catálogo controlada pelo -sync
+type syncEntity struct {
+	name     string // tipo usado em sync.prune
+	path     string
+	keyField string // chave natural usada para casar com o seed
+}
+
+// syncEntities na ordem de deleção: dependentes antes das entidades que referenciam
+var syncEntities = []syncEntity{
+	{name: "product", path: "/product", keyField: "name"},
+	{name: "tag", path: "/tag", keyField: "name"},
+	{name: "table", path: "/table", keyField: "number"},
+	{name: "subcategory", path: "/subcategory", keyField: "name"},
+	{name: "category", path: "/category", keyField: "name"},
+	{name: "menu", path: "/menu", keyField: "name"},
+	{name: "environment", path: "/environment", keyField: "name"},
+}
+
+func syncEntityNames() []string {
+	names := make([]string, len(syncEntities))
+	for i, entity := range syncEntities {
+		names[i] = entity.name
+	}
+	return names
+}
+
+func isSyncEntity(name string) bool {
+	for _, entity := range syncEntities {
+		if entity.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// syncAction é uma alteração do plano de sincronização
+type syncAction struct {
+	entity  syncEntity
+	id      string
+	key     string
+	current map[string]interface{}
+	changes map[string]interface{} // vazio para deleção
+}
+
+// syncPlan agrupa as alterações calculadas para o projeto
+type syncPlan struct {
+	updates []syncAction
+	deletes []syncAction
+	kept    map[string]int // tipo -> entidades fora do seed preservadas (fora de sync.prune)
+}
+
+// syncProject compara o catálogo do projeto com o seed, exibe o plano e o aplica.
+// Atualizações são aplicadas sempre; deleções somente com -yes.
+func (s *SeedServiceV2) syncProject(ctx context.Context, ids map[string]map[int]string) {
+	// Seed só com "projects": o projeto do login não tem catálogo declarado e o sync apagaria tudo dele
+	if len(s.seedData.Projects) > 0 && !s.seedData.declaresCatalog() {
+		s.logger.Info("Seed sem catálogo no nível superior (apenas projects): projeto do login não sincronizado")
+		return
+	}
+
+	plan, err := s.buildSyncPlan(ctx, ids)
+	if err != nil {
+		s.recordStepError("sync", "plano", err)
+		return
+	}
+
+	s.printSyncPlan(plan)
+
+	for _, action := range plan.updates {
+		if s.interrupted(ctx) {
+			break
+		}
+
+		if err := s.client.UpdateEntity(ctx, action.entity.path, action.id, mergeFields(action.current, action.changes)); err != nil {
+			s.recordStepError("sync", action.entity.name+" "+action.key, err)
+			continue
+		}
+		s.logger.Info("Atualizado: %s %s", action.entity.name, action.key)
+		s.state.updated++
+	}
+
+	if len(plan.deletes) == 0 {
+		return
+	}
+	if !s.config.Sync.Confirm {
+		s.logger.Warn("%d deleção(ões) pendente(s). Rode novamente com -yes para aplicá-las", len(plan.deletes))
+		return
+	}
+
+	for _, action := range plan.deletes {
+		if s.interrupted(ctx) {
+			break
+		}
+
+		if err := s.client.DeleteEntity(ctx, action.entity.path, action.id); err != nil {
+			s.recordStepError("sync", action.entity.name+" "+action.key, err)
+			continue
+		}
+		s.logger.Info("Apagado: %s %s", action.entity.name, action.key)
+	}
+}
+
+// declaresCatalog indica se o seed declara alguma seção sincronizada pelo -sync
+func (s *SeedData) declaresCatalog() bool {
+	return len(s.Menus)+len(s.Categories)+len(s.Subcategories)+len(s.Environments)+
+		len(s.Tables)+len(s.Products)+len(s.Tags) > 0
+}
+
+// syncTarget é uma entidade declarada no seed: o ID resolvido pelos passos do seed e os campos controlados
+type syncTarget struct {
+	id     string // vazio quando a entidade não foi resolvida (erro ou interrupção)
+	fields map[string]interface{}
+}
+
+// buildSyncPlan lista cada coleção e calcula atualizações e deleções.
+// A entidade mantida é sempre a resolvida pelos passos do seed (ids), nunca a primeira da listagem;
+// entidades resolvidas jamais são apagadas, e cópias de uma chave não resolvida são preservadas.
+func (s *SeedServiceV2) buildSyncPlan(ctx context.Context, ids map[string]map[int]string) (*syncPlan, error) {
+	prune := make(map[string]bool, len(s.config.Sync.Prune))
+	for _, name := range s.config.Sync.Prune {
+		prune[name] = true
+	}
+
+	plan := &syncPlan{kept: make(map[string]int)}
+	for _, entity := range syncEntities {
+		desired := s.syncDesired(entity.name, ids)
+
+		items, err := s.client.listAll(ctx, entity.path, nil)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao listar %s: %w", entity.path, err)
+		}
+
+		resolved := make(map[string]bool, len(ids[entity.name]))
+		for _, id := range ids[entity.name] {
+			resolved[id] = true
+		}
+
+		for _, item := range items {
+			id, _ := item["id"].(string)
+			key, ok := cacheKeyValue(item[entity.keyField])
+			if !ok || id == "" {
+				continue
+			}
+
+			target, declared := desired[key]
+			if resolved[id] {
+				if declared && target.id == id {
+					if changes := syncChanges(item, target.fields); len(changes) > 0 {
+						plan.updates = append(plan.updates, syncAction{entity: entity, id: id, key: key, current: item, changes: changes})
+					}
+				}
+				continue
+			}
+
+			// Chave declarada sem ID resolvido: não há como saber qual cópia é a do seed
+			if declared && target.id == "" {
+				plan.kept[entity.name]++
+				continue
+			}
+
+			// Fora do seed (ou duplicata da entidade resolvida)
+			if !prune[entity.name] {
+				plan.kept[entity.name]++
+				continue
+			}
+			plan.deletes = append(plan.deletes, syncAction{entity: entity, id: id, key: key, current: item})
+		}
+	}
+
+	return plan, nil
+}
+
+// syncDesired retorna, por chave natural, o ID resolvido e os campos que o seed controla para cada entidade declarada
+func (s *SeedServiceV2) syncDesired(entity string, ids map[string]map[int]string) map[string]syncTarget {
+	desired := make(map[string]syncTarget)
+	ref := func(kind string, idx int) *string {
+		if id, ok := ids[kind][idx]; ok {
+			return &id
+		}
+		return nil
+	}
+	add := func(key string, idx int, fields map[string]interface{}) {
+		// Chave repetida no seed: prevalece a entrada com ID resolvido
+		if existing, ok := desired[key]; ok && existing.id != "" {
+			return
+		}
+		desired[key] = syncTarget{id: ids[entity][idx], fields: fields}
+	}
+
+	switch entity {
+	case "menu":
+		for i, m := range s.seedData.Menus {
+			add(m.Name, i, map[string]interface{}{"order": m.Order})
+		}
+	case "category":
+		for i, c := range s.seedData.Categories {
+			fields := map[string]interface{}{"order": c.Order}
+			if menuID := ref("menu", c.MenuIDRef); menuID != nil {
+				fields["menu_id"] = *menuID
+			}
+			add(c.Name, i, fields)
+		}
+	case "subcategory":
+		for i, sc := range s.seedData.Subcategories {
+			add(sc.Name, i, map[string]interface{}{})
+		}
+	case "environment":
+		for i, e := range s.seedData.Environments {
+			add(e.Name, i, map[string]interface{}{"capacity": e.Capacity})
+		}
+	case "table":
+		for i, t := range s.seedData.Tables {
+			fields := map[string]interface{}{"capacity": t.Capacity}
+			if t.Status != "" {
+				fields["status"] = t.Status
+			}
+			if envID := ref("environment", t.EnvironmentIDRef); envID != nil {
+				fields["environment_id"] = *envID
+			}
+			add(strconv.Itoa(t.Number), i, fields)
+		}
+	case "product":
+		for i, p := range s.seedData.Products {
+			fields := BuildProductPayload(p, ref("menu", p.MenuIDRef), ref("category", p.CategoryIDRef), ref("subcategory", p.SubcategoryIDRef))
+			delete(fields, "name")
+			add(p.Name, i, fields)
+		}
+	case "tag":
+		for i, t := range s.seedData.Tags {
+			fields := map[string]interface{}{}
+			if t.Color != "" {
+				fields["color"] = t.Color
+			}
+			if t.Description != "" {
+				fields["description"] = t.Description
+			}
+			if t.EntityType != "" {
+				fields["entity_type"] = t.EntityType
+			}
+			add(t.Name, i, fields)
+		}
+	}
+
+	return desired
+}
+
+// syncChanges retorna os campos desejados que diferem do estado atual
+func syncChanges(current, desired map[string]interface{}) map[string]interface{} {
+	changes := make(map[string]interface{})
+	for field, want := range desired {
+		if !syncValueEqual(current[field], want) {
+			changes[field] = want
+		}
+	}
+	return changes
+}
+
+// syncValueEqual compara valores pela forma JSON (o backend devolve números como float64)
+func syncValueEqual(a, b interface{}) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(ja) == string(jb)
+}
+
+// mergeFields aplica as alterações sobre a entidade atual, para que o PUT não apague campos fora do seed
+func mergeFields(current, changes map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(current)+len(changes))
+	for k, v := range current {
+		merged[k] = v
+	}
+	for k, v := range changes {
+		merged[k] = v
+	}
+	return merged
+}
+
+// printSyncPlan exibe o plano antes de qualquer alteração
+func (s *SeedServiceV2) printSyncPlan(plan *syncPlan) {
+	s.logger.Info("Plano de sincronização:")
+	if len(plan.updates) == 0 && len(plan.deletes) == 0 {
+		s.logger.Info("    Nada a alterar: o projeto já corresponde ao seed")
+	}
+
+	for _, a := range plan.updates {
+		fields := make([]string, 0, len(a.changes))
+		for field, want := range a.changes {
+			fields = append(fields, fmt.Sprintf("%s: %v -> %v", field, a.current[field], want))
+		}
+		sort.Strings(fields)
+		s.logger.With(Fields{"entity": a.entity.name, "action": "update"}).Info("    ~ atualizar %s %s (%s)", a.entity.name, a.key, strings.Join(fields, ", "))
+	}
+
+	for _, a := range plan.deletes {
+		s.logger.With(Fields{"entity": a.entity.name, "action": "delete"}).Info("    - apagar %s %s", a.entity.name, a.key)
+	}
+
+	for _, entity := range syncEntities {
+		if n := plan.kept[entity.name]; n > 0 {
+			s.logger.With(Fields{"entity": entity.name, "action": "keep"}).Info("    = manter %d %s fora do seed (tipo fora de sync.prune)", n, entity.name)
+		}
+	}
+}
+
+// UpdateEntity substitui uma entidade (PUT /{entity}/{id})
+func (c *APIClientV2) UpdateEntity(ctx context.Context, path, id string, payload map[string]interface{}) error {
+	resp, status, err := c.doRequest(ctx, "PUT", path+"/"+id, payload)
+	if err != nil {
+		return err
+	}
+
+	if status != 200 && status != 201 {
+		return newAPIError(status, resp)
+	}
+
+	return nil
+}
+
+// DeleteEntity apaga uma entidade (DELETE /{entity}/{id}); 404 significa que já não existe
+func (c *APIClientV2) DeleteEntity(ctx context.Context, path, id string) error {
+	resp, status, err := c.doRequest(ctx, "DELETE", path+"/"+id, nil)
+	if err != nil {
+		return err
+	}
+
+	if status != 200 && status != 204 && status != 404 {
+		return newAPIError(status, resp)
+	}
+
+	return nil
+}
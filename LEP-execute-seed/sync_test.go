@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSyncProjectDeletes(t *testing.T) {
+	const (
+		risotoID = "11111111-1111-1111-1111-111111111111"
+		antigaID = "22222222-2222-2222-2222-222222222222"
+	)
+
+	tests := []struct {
+		name string
+		seed SeedData
+		ids  map[string]map[int]string
+		want []string // DELETEs enviados
+	}{
+		{
+			name: "seed apenas com projects não sincroniza o projeto do login",
+			seed: SeedData{Projects: []ProjectSeedData{{Name: "Filial", SeedData: SeedData{Products: []ProductData{{Name: "Risoto"}}}}}},
+			ids:  map[string]map[int]string{},
+		},
+		{
+			name: "catálogo no nível superior apaga o que não foi declarado",
+			seed: SeedData{
+				Products: []ProductData{{Name: "Risoto"}},
+				Projects: []ProjectSeedData{{Name: "Filial"}},
+			},
+			ids:  map[string]map[int]string{"product": {0: risotoID}},
+			want: []string{"/product/" + antigaID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var deletes []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodDelete:
+					mu.Lock()
+					deletes = append(deletes, r.URL.Path)
+					mu.Unlock()
+					w.Write([]byte(`{}`))
+				case strings.HasPrefix(r.URL.Path, "/product"):
+					w.Write([]byte(`{"data": [{"id": "` + risotoID + `", "name": "Risoto"}, {"id": "` + antigaID + `", "name": "Pizza Antiga"}]}`))
+				default:
+					w.Write([]byte(`{"data": []}`))
+				}
+			}))
+			defer srv.Close()
+
+			cfg := &Config{}
+			cfg.Retry.MaxAttempts = 1
+			cfg.Seed.Cache = true
+			cfg.Sync.Enabled = true
+			cfg.Sync.Confirm = true
+			cfg.Sync.Prune = []string{"product"}
+
+			logger := NewLogger(false)
+			seed := tt.seed
+			s := &SeedServiceV2{client: NewAPIClientV2(srv.URL, logger, cfg), logger: logger, config: cfg, seedData: &seed, state: &SeedState{}}
+			ctx := s.beginStep(context.Background(), "Passo 19: Sincronizando Catálogo")
+
+			s.syncProject(ctx, tt.ids)
+
+			if !reflect.DeepEqual(deletes, tt.want) {
+				t.Errorf("DELETEs = %v, want %v", deletes, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// taggableEntityCounts retorna, por tipo de entidade que aceita tags, quantos itens o seed declara
+func (s *SeedData) taggableEntityCounts() map[string]int {
+	return map[string]int{
+		"product":     len(s.Products),
+		"menu":        len(s.Menus),
+		"customer":    len(s.Customers),
+		"table":       len(s.Tables),
+		"reservation": len(s.Reservations),
+	}
+}
+
+// ValidateTagAssignments verifica referências e compatibilidade de entity_type das tags.
+// Tag sem entity_type pode ser usada em qualquer entidade.
+func (s *SeedData) ValidateTagAssignments() error {
+	counts := s.taggableEntityCounts()
+
+	checkTag := func(where string, tagRef int, target string) error {
+		if tagRef < 0 || tagRef >= len(s.Tags) {
+			return fmt.Errorf("%s: tag_id_ref %d fora do intervalo", where, tagRef)
+		}
+		tag := s.Tags[tagRef]
+		if tag.EntityType != "" && tag.EntityType != target {
+			return fmt.Errorf("%s: tag %q é do tipo %q e não pode ser aplicada a %q", where, tag.Name, tag.EntityType, target)
+		}
+		return nil
+	}
+
+	for i, pt := range s.ProductTags {
+		if err := checkTag(fmt.Sprintf("product_tags[%d]", i), pt.TagIDRef, "product"); err != nil {
+			return err
+		}
+	}
+
+	for i, a := range s.TagAssignments {
+		where := fmt.Sprintf("tag_assignments[%d]", i)
+
+		count, ok := counts[a.EntityType]
+		if !ok {
+			return fmt.Errorf("%s: entity_type %q não aceita tags (use product, menu, customer, table ou reservation)", where, a.EntityType)
+		}
+		if a.EntityIDRef < 0 || a.EntityIDRef >= count {
+			return fmt.Errorf("%s: entity_id_ref %d fora do intervalo de %s", where, a.EntityIDRef, a.EntityType)
+		}
+		if err := checkTag(where, a.TagIDRef, a.EntityType); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// seedTagAssignments aplica tag_assignments usando os IDs resolvidos de cada entidade
+func (s *SeedServiceV2) seedTagAssignments(ctx context.Context, tagIDs map[int]string, entityIDs map[string]map[int]string) {
+	if len(s.seedData.TagAssignments) == 0 {
+		s.logger.Info("Nenhum TagAssignment definido no seed")
+		return
+	}
+
+	for _, a := range s.seedData.TagAssignments {
+		if s.interrupted(ctx) {
+			break
+		}
+
+		tagName := s.seedData.Tags[a.TagIDRef].Name
+		item := fmt.Sprintf("%s[%d] <- %s", a.EntityType, a.EntityIDRef, tagName)
+
+		entityID, ok := entityIDs[a.EntityType][a.EntityIDRef]
+		if !ok {
+			s.logger.Error("Entidade %s não encontrada para tag", item)
+			s.state.failed++
+			continue
+		}
+
+		tagID, ok := tagIDs[a.TagIDRef]
+		if !ok {
+			s.logger.Error("Tag %s não encontrada", tagName)
+			s.state.failed++
+			continue
+		}
+
+		if err := s.client.AddTagToEntity(ctx, a.EntityType, entityID, tagID); err != nil {
+			s.logger.Error("Erro ao vincular tag %s: %v", item, err)
+			s.state.failed++
+			s.state.errors = append(s.state.errors, newSeedError("tag_assignment", item, err))
+			continue
+		}
+
+		s.logger.Info("Tag %s vinculada a %s %s", tagName, a.EntityType, entityID)
+		s.state.created++
+	}
+}
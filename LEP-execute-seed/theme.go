@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// ThemePalette é o conjunto de cores de um modo (light ou dark)
+type ThemePalette struct {
+	Primary        string
+	Secondary      string
+	Background     string
+	CardBackground string
+	Text           string
+	TextSecondary  string
+	Accent         string
+	Success        string
+	Error          string
+	Warning        string
+	Info           string
+}
+
+// Luminosidade (HSL) usada ao derivar superfícies e textos dark a partir da cor light.
+// Com uma cor light neutra (branco/cinza) o resultado é #1a1a1a, #2d2d2d, #f0f0f0 e #b0b0b0,
+// os valores que o seeder usava fixos antes da paleta dark existir.
+const (
+	darkBackgroundLightness     = 0.10
+	darkCardBackgroundLightness = 0.176
+	darkTextLightness           = 0.94
+	darkTextSecondaryLightness  = 0.69
+
+	// Saturação máxima das cores dark derivadas: mantém o tom da marca sem produzir fundos coloridos demais
+	darkMaxSaturation = 0.20
+)
+
+// LightPalette retorna as cores light declaradas no seed
+func (t *ThemeCustomizationData) LightPalette() ThemePalette {
+	return ThemePalette{
+		Primary:        t.PrimaryColor,
+		Secondary:      t.SecondaryColor,
+		Background:     t.BackgroundColor,
+		CardBackground: t.CardBackgroundColor,
+		Text:           t.TextColor,
+		TextSecondary:  t.TextSecondaryColor,
+		Accent:         t.AccentColor,
+		Success:        t.SuccessColor,
+		Error:          t.ErrorColor,
+		Warning:        t.WarningColor,
+		Info:           t.InfoColor,
+	}
+}
+
+// DarkPalette retorna as cores dark, usando a cor declarada em *_dark quando existir.
+//
+// Regra de derivação para cores dark omitidas:
+//   - Cores de marca e de status (primary, secondary, accent, success, error, warning, info)
+//     repetem a cor light, preservando a identidade visual nos dois modos.
+//   - Fundos e textos mantêm o matiz da cor light, com saturação limitada a 20% e luminosidade fixa:
+//     background 10%, card 17,6%, texto 94% e texto secundário 69%.
+func (t *ThemeCustomizationData) DarkPalette() ThemePalette {
+	light := t.LightPalette()
+
+	pick := func(dark, fallback string) string {
+		if dark != "" {
+			return dark
+		}
+		return fallback
+	}
+
+	return ThemePalette{
+		Primary:        pick(t.PrimaryColorDark, light.Primary),
+		Secondary:      pick(t.SecondaryColorDark, light.Secondary),
+		Background:     pick(t.BackgroundColorDark, deriveDarkColor(light.Background, darkBackgroundLightness)),
+		CardBackground: pick(t.CardBackgroundColorDark, deriveDarkColor(light.CardBackground, darkCardBackgroundLightness)),
+		Text:           pick(t.TextColorDark, deriveDarkColor(light.Text, darkTextLightness)),
+		TextSecondary:  pick(t.TextSecondaryColorDark, deriveDarkColor(light.TextSecondary, darkTextSecondaryLightness)),
+		Accent:         pick(t.AccentColorDark, light.Accent),
+		Success:        pick(t.SuccessColorDark, light.Success),
+		Error:          pick(t.ErrorColorDark, light.Error),
+		Warning:        pick(t.WarningColorDark, light.Warning),
+		Info:           pick(t.InfoColorDark, light.Info),
+	}
+}
+
+// deriveDarkColor mantém o matiz da cor light e aplica a luminosidade do modo dark.
+// Cor light ausente ou inválida é tratada como neutra.
+func deriveDarkColor(lightHex string, lightness float64) string {
+	var h, s float64
+	if c, err := parseHexColor(lightHex); err == nil {
+		h, s, _ = rgbToHSL(c)
+	}
+
+	return formatHexColor(hslToRGB(h, math.Min(s, darkMaxSaturation), lightness))
+}
+
+// Validate verifica se todas as cores declaradas são hexadecimais válidas
+func (t *ThemeCustomizationData) Validate() error {
+	fields := []struct {
+		name  string
+		value string
+	}{
+		{"primary_color", t.PrimaryColor},
+		{"secondary_color", t.SecondaryColor},
+		{"background_color", t.BackgroundColor},
+		{"card_background_color", t.CardBackgroundColor},
+		{"text_color", t.TextColor},
+		{"text_secondary_color", t.TextSecondaryColor},
+		{"accent_color", t.AccentColor},
+		{"success_color", t.SuccessColor},
+		{"error_color", t.ErrorColor},
+		{"warning_color", t.WarningColor},
+		{"info_color", t.InfoColor},
+		{"primary_color_dark", t.PrimaryColorDark},
+		{"secondary_color_dark", t.SecondaryColorDark},
+		{"background_color_dark", t.BackgroundColorDark},
+		{"card_background_color_dark", t.CardBackgroundColorDark},
+		{"text_color_dark", t.TextColorDark},
+		{"text_secondary_color_dark", t.TextSecondaryColorDark},
+		{"accent_color_dark", t.AccentColorDark},
+		{"success_color_dark", t.SuccessColorDark},
+		{"error_color_dark", t.ErrorColorDark},
+		{"warning_color_dark", t.WarningColorDark},
+		{"info_color_dark", t.InfoColorDark},
+	}
+
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		if _, err := parseHexColor(f.value); err != nil {
+			return fmt.Errorf("theme_customization.%s: %w", f.name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,3 @@
+module lep-shared
+
+go 1.21
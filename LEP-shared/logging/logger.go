@@ -0,0 +1,281 @@
+// Package logging é o logger comum ao seeder (LEP-execute-seed) e à suíte de testes (LEP-teste-back):
+// níveis, campos estruturados, formato JSON, NO_COLOR e arquivo de log.
+// Cabeçalhos e resumos ficam em cada ferramenta, escritos com Event e Printf.
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level é o nível mínimo de uma entrada para ser exibida
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var levelNames = map[Level]string{
+	LevelDebug: "debug",
+	LevelInfo:  "info",
+	LevelWarn:  "warn",
+	LevelError: "error",
+}
+
+func (l Level) String() string {
+	return levelNames[l]
+}
+
+// ParseLevel interpreta debug (ou verbose), info, warn (ou warning) e error
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug", "verbose":
+		return LevelDebug, nil
+	case "", "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	}
+	return LevelInfo, fmt.Errorf("nível de log inválido %q (use debug, info, warn ou error)", s)
+}
+
+// Options configura o Logger
+type Options struct {
+	Level      Level
+	JSON       bool   // uma entrada JSON por linha, sem cores
+	File       string // também grava as entradas neste arquivo (sem cores)
+	Timestamps bool   // prefixa o horário no formato texto
+}
+
+// Fields são campos estruturados da entrada (step, entity, test, method, path, status, duration_ms)
+type Fields map[string]interface{}
+
+// Códigos ANSI para os cabeçalhos de cada ferramenta; use com Color
+const (
+	ColorReset  = "\033[0m"
+	ColorBold   = "\033[1m"
+	ColorGreen  = "\033[32m"
+	ColorRed    = "\033[31m"
+	ColorYellow = "\033[33m"
+	ColorBlue   = "\033[34m"
+	ColorCyan   = "\033[36m"
+)
+
+// Logger escreve entradas com nível, em texto colorido ou JSON.
+// Cores só são usadas quando a saída é um terminal e NO_COLOR não está definido.
+type Logger struct {
+	out    *output
+	fields Fields
+}
+
+// output é compartilhado entre o Logger e os derivados de With
+type output struct {
+	mu         sync.Mutex
+	level      Level
+	json       bool
+	color      bool
+	timestamps bool
+	console    io.Writer
+	file       *os.File
+	step       string
+}
+
+// New cria o logger; falha apenas se o arquivo de log não puder ser aberto
+func New(opts Options) (*Logger, error) {
+	out := &output{
+		level:      opts.Level,
+		json:       opts.JSON,
+		color:      !opts.JSON && os.Getenv("NO_COLOR") == "" && isTerminal(os.Stdout),
+		timestamps: opts.Timestamps,
+		console:    os.Stdout,
+	}
+
+	if opts.File != "" {
+		f, err := os.OpenFile(opts.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao abrir arquivo de log: %w", err)
+		}
+		out.file = f
+	}
+
+	return &Logger{out: out}, nil
+}
+
+// isTerminal indica se o arquivo é um terminal (e não um pipe ou arquivo)
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Close fecha o arquivo de log, se houver
+func (l *Logger) Close() error {
+	if l.out.file == nil {
+		return nil
+	}
+	return l.out.file.Close()
+}
+
+// With retorna um logger que anexa os campos a todas as entradas
+func (l *Logger) With(fields Fields) *Logger {
+	merged := make(Fields, len(l.fields)+len(fields))
+	for k, v := range l.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return &Logger{out: l.out, fields: merged}
+}
+
+// SetStep define o passo atual, anexado como campo "step" às entradas seguintes
+func (l *Logger) SetStep(step string) {
+	l.out.mu.Lock()
+	l.out.step = step
+	l.out.mu.Unlock()
+}
+
+// JSON indica se o logger está no modo JSON
+func (l *Logger) JSON() bool {
+	return l.out.json
+}
+
+// Enabled indica se entradas do nível são exibidas
+func (l *Logger) Enabled(level Level) bool {
+	return level >= l.out.level
+}
+
+func (l *Logger) Info(format string, args ...interface{}) {
+	l.log(LevelInfo, "", ColorBlue, "[ℹ]", fmt.Sprintf(format, args...), nil)
+}
+
+func (l *Logger) Success(format string, args ...interface{}) {
+	l.log(LevelInfo, "success", ColorGreen, "[✓]", fmt.Sprintf(format, args...), nil)
+}
+
+func (l *Logger) Error(format string, args ...interface{}) {
+	l.log(LevelError, "", ColorRed, "[✗]", fmt.Sprintf(format, args...), nil)
+}
+
+func (l *Logger) Warn(format string, args ...interface{}) {
+	l.log(LevelWarn, "", ColorYellow, "[⚠]", fmt.Sprintf(format, args...), nil)
+}
+
+func (l *Logger) Skip(format string, args ...interface{}) {
+	l.log(LevelInfo, "skip", ColorCyan, "[⏭]", fmt.Sprintf(format, args...), nil)
+}
+
+func (l *Logger) Debug(format string, args ...interface{}) {
+	l.log(LevelDebug, "", ColorYellow, "[D]", fmt.Sprintf(format, args...), nil)
+}
+
+// Event escreve uma entrada JSON com o evento (section, summary, stats etc.) e campos extras
+func (l *Logger) Event(level Level, event, msg string, fields Fields) {
+	l.log(level, event, "", "", msg, fields)
+}
+
+// Color retorna o código ANSI apenas quando cores estão habilitadas
+func (l *Logger) Color(code string) string {
+	if l.out.color {
+		return code
+	}
+	return ""
+}
+
+// Printf escreve texto livre (cabeçalhos e resumos); no arquivo de log vai sem cores
+func (l *Logger) Printf(level Level, format string, args ...interface{}) {
+	if !l.Enabled(level) {
+		return
+	}
+	line := fmt.Sprintf(format, args...)
+
+	l.out.mu.Lock()
+	defer l.out.mu.Unlock()
+	fmt.Fprint(l.out.console, line)
+	if l.out.file != nil {
+		fmt.Fprint(l.out.file, stripANSI(line))
+	}
+}
+
+// log escreve uma entrada; event distingue success, skip, section etc. no JSON
+func (l *Logger) log(level Level, event, color, icon, msg string, extra Fields) {
+	if !l.Enabled(level) {
+		return
+	}
+
+	l.out.mu.Lock()
+	defer l.out.mu.Unlock()
+
+	fields := make(Fields, len(l.fields)+len(extra)+1)
+	if l.out.step != "" {
+		fields["step"] = l.out.step
+	}
+	for k, v := range l.fields {
+		fields[k] = v
+	}
+	for k, v := range extra {
+		fields[k] = v
+	}
+
+	if l.out.json {
+		entry := make(Fields, len(fields)+4)
+		for k, v := range fields {
+			entry[k] = v
+		}
+		entry["time"] = time.Now().Format(time.RFC3339Nano)
+		entry["level"] = level.String()
+		entry["msg"] = msg
+		if event != "" {
+			entry["event"] = event
+		}
+		data, err := json.Marshal(entry)
+		if err != nil {
+			data = []byte(fmt.Sprintf(`{"level":"error","msg":"erro ao serializar log: %v"}`, err))
+		}
+		fmt.Fprintf(l.out.console, "%s\n", data)
+		if l.out.file != nil {
+			fmt.Fprintf(l.out.file, "%s\n", data)
+		}
+		return
+	}
+
+	// No texto os campos ficam de fora; eles existem para filtrar o log JSON
+	prefix := ""
+	if l.out.timestamps {
+		prefix = fmt.Sprintf("[%s] ", time.Now().Format("15:04:05.000"))
+	}
+	if l.out.color {
+		fmt.Fprintf(l.out.console, "%s%s%s%s %s\n", prefix, color, icon, ColorReset, msg)
+	} else {
+		fmt.Fprintf(l.out.console, "%s%s %s\n", prefix, icon, msg)
+	}
+	if l.out.file != nil {
+		fmt.Fprintf(l.out.file, "%s %-5s %s %s\n", time.Now().Format(time.RFC3339), strings.ToUpper(level.String()), icon, msg)
+	}
+}
+
+// stripANSI remove códigos de cor de um texto já formatado
+func stripANSI(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\033' && i+1 < len(s) && s[i+1] == '[' {
+			j := i + 2
+			for j < len(s) && s[j] != 'm' {
+				j++
+			}
+			i = j
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,117 @@
+// Package retry decide quando e quanto esperar para repetir requisições ao backend.
+// É usado pelo seeder (LEP-execute-seed) e pela suíte de testes (LEP-teste-back).
+package retry
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Policy define quantas vezes e com qual espera uma requisição transitória é repetida
+type Policy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// Backoff retorna a espera antes da tentativa seguinte (exponencial com jitter)
+// attempt começa em 1 (primeira tentativa que falhou)
+func (p Policy) Backoff(attempt int) time.Duration {
+	delay := p.InitialBackoff
+	for i := 1; i < attempt && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+
+	// Jitter: espera aleatória entre metade e o total do delay,
+	// para que vários clientes não martelem o backend em sincronia
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// Delay retorna a espera antes da tentativa seguinte. O Retry-After do backend tem precedência
+// sobre o backoff, mas nunca passa de MaxBackoff.
+func (p Policy) Delay(attempt int, header http.Header, now time.Time) time.Duration {
+	retryAfter, ok := parseRetryAfter(header, now)
+	if !ok {
+		return p.Backoff(attempt)
+	}
+	if retryAfter > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return retryAfter
+}
+
+// Sleep espera o backoff, retornando antes com o erro do contexto se ele for cancelado
+func Sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// IsRetryableStatus indica se o status HTTP é transitório
+func IsRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// IsIdempotentMethod indica se repetir o método não altera o resultado no backend
+func IsIdempotentMethod(method string) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// RequestNeverLanded indica se há garantia de que a requisição não chegou a ser processada.
+// Isso vale para falhas de conexão (dial) e para 429, em que o rate limiter rejeita antes do handler.
+func RequestNeverLanded(status int, err error) bool {
+	if err != nil {
+		var opErr *net.OpError
+		return errors.As(err, &opErr) && opErr.Op == "dial"
+	}
+	return status == http.StatusTooManyRequests
+}
+
+// parseRetryAfter interpreta o header Retry-After (segundos ou data HTTP)
+func parseRetryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if when, err := http.ParseTime(value); err == nil {
+		delay := when.Sub(now)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
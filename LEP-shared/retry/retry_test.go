@@ -0,0 +1,72 @@
+package retry
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 3, 14, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "ausente", value: ""},
+		{name: "segundos", value: "5", want: 5 * time.Second, wantOK: true},
+		{name: "segundos com espaços", value: " 2 ", want: 2 * time.Second, wantOK: true},
+		{name: "zero", value: "0", want: 0, wantOK: true},
+		{name: "negativo", value: "-3"},
+		{name: "data HTTP futura", value: now.Add(90 * time.Second).Format(http.TimeFormat), want: 90 * time.Second, wantOK: true},
+		{name: "data HTTP passada", value: now.Add(-time.Minute).Format(http.TimeFormat), want: 0, wantOK: true},
+		{name: "inválido", value: "amanhã"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.value != "" {
+				header.Set("Retry-After", tt.value)
+			}
+
+			got, ok := parseRetryAfter(header, now)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestPolicyDelay(t *testing.T) {
+	policy := Policy{MaxAttempts: 4, InitialBackoff: time.Second, MaxBackoff: 8 * time.Second}
+	now := time.Date(2026, 3, 14, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		retryAfter string
+		want       time.Duration
+	}{
+		{name: "Retry-After dentro do limite", retryAfter: "3", want: 3 * time.Second},
+		{name: "Retry-After acima do limite", retryAfter: "3600", want: 8 * time.Second},
+		{name: "data acima do limite", retryAfter: now.Add(time.Hour).Format(http.TimeFormat), want: 8 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{"Retry-After": []string{tt.retryAfter}}
+			if got := policy.Delay(1, header, now); got != tt.want {
+				t.Errorf("Delay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("sem Retry-After usa o backoff", func(t *testing.T) {
+		got := policy.Delay(2, http.Header{}, now)
+		if got < time.Second || got > 2*time.Second {
+			t.Errorf("Delay() = %v, want entre 1s e 2s", got)
+		}
+	})
+}
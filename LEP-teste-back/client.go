@@ -8,6 +8,8 @@ import (
 	"mime/multipart"
 	"net/http"
 	"time"
+
+	"lep-shared/retry"
 )
 
 type APIClient struct {
@@ -18,6 +20,7 @@ type APIClient struct {
 	logger     *Logger
 	client     *http.Client
 	lastStatus int // Armazenar último status HTTP
+	retry      retry.Policy
 }
 
 func NewAPIClient(baseURL string, logger *Logger) *APIClient {
@@ -27,9 +30,15 @@ func NewAPIClient(baseURL string, logger *Logger) *APIClient {
 		client: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		retry: DefaultRetryPolicy(1),
 	}
 }
 
+// SetRetryPolicy define a política de retry para falhas transitórias
+func (c *APIClient) SetRetryPolicy(policy retry.Policy) {
+	c.retry = policy
+}
+
 func (c *APIClient) SetHeaders(orgID, projID, token string) {
 	c.orgID = orgID
 	c.projID = projID
@@ -46,54 +55,88 @@ func (c *APIClient) Request(method, path string, body interface{}, requiresAuth
 	url := c.baseURL + path
 
 	// Preparar body
-	var reqBody io.Reader
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			c.logger.Error("Erro ao marshal body: %v", err)
 			return nil, err
 		}
-		reqBody = bytes.NewBuffer(jsonBody)
 		c.logger.Debug("Request body: %s", string(jsonBody))
 	}
 
-	// Criar request
-	req, err := http.NewRequest(method, url, reqBody)
-	if err != nil {
-		c.logger.Error("Erro ao criar request: %v", err)
-		return nil, err
+	if requiresAuth && c.token == "" {
+		c.logger.Error("Token não disponível para requisição autenticada")
+		return nil, fmt.Errorf("token não disponível")
 	}
 
-	// Adicionar headers
-	req.Header.Set("Content-Type", "application/json")
+	// Executar request com retry para falhas transitórias.
+	// POSTs só são repetidos quando é certo que não chegaram ao backend (falha de conexão ou 429).
+	var resp *http.Response
+	var duration time.Duration
+	for attempt := 1; ; attempt++ {
+		var reqBody io.Reader
+		if jsonBody != nil {
+			reqBody = bytes.NewReader(jsonBody)
+		}
 
-	if requiresAuth {
-		if c.token == "" {
-			c.logger.Error("Token não disponível para requisição autenticada")
-			return nil, fmt.Errorf("token não disponível")
+		// Criar request
+		req, err := http.NewRequest(method, url, reqBody)
+		if err != nil {
+			c.logger.Error("Erro ao criar request: %v", err)
+			return nil, err
 		}
-		req.Header.Set("Authorization", "Bearer "+c.token)
-		c.logger.Debug("Headers: Authorization: Bearer %s...", c.token[:20])
-	}
 
-	// Headers multi-tenant (exceto para login)
-	if c.orgID != "" && c.projID != "" && requiresAuth {
-		req.Header.Set("X-Lpe-Organization-Id", c.orgID)
-		req.Header.Set("X-Lpe-Project-Id", c.projID)
-		c.logger.Debug("Headers: Org-Id: %s, Proj-Id: %s", c.orgID[:8], c.projID[:8])
-	}
+		// Adicionar headers
+		req.Header.Set("Content-Type", "application/json")
 
-	// Log da requisição
-	c.logger.Info("%s %s", method, path)
+		if requiresAuth {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+			c.logger.Debug("Headers: Authorization: Bearer %s...", c.token[:20])
+		}
 
-	// Executar request
-	start := time.Now()
-	resp, err := c.client.Do(req)
-	duration := time.Since(start)
+		// Headers multi-tenant (exceto para login)
+		if c.orgID != "" && c.projID != "" && requiresAuth {
+			req.Header.Set("X-Lpe-Organization-Id", c.orgID)
+			req.Header.Set("X-Lpe-Project-Id", c.projID)
+			c.logger.Debug("Headers: Org-Id: %s, Proj-Id: %s", c.orgID[:8], c.projID[:8])
+		}
 
-	if err != nil {
-		c.logger.Error("Erro ao executar request: %v", err)
-		return nil, err
+		// Log da requisição
+		c.logger.Info("%s %s", method, path)
+
+		start := time.Now()
+		resp, err = c.client.Do(req)
+		duration = time.Since(start)
+
+		status := 0
+		if err == nil {
+			status = resp.StatusCode
+		}
+
+		transient := err != nil || retry.IsRetryableStatus(status)
+		safe := retry.IsIdempotentMethod(method) || retry.RequestNeverLanded(status, err)
+		if !transient || !safe || attempt >= c.retry.MaxAttempts {
+			if err != nil {
+				c.logger.Error("Erro ao executar request: %v", err)
+				return nil, err
+			}
+			break
+		}
+
+		delay := c.retry.Backoff(attempt)
+		if err == nil {
+			delay = c.retry.Delay(attempt, resp.Header, time.Now())
+			resp.Body.Close()
+		}
+
+		failure := fmt.Sprintf("status %d", status)
+		if err != nil {
+			failure = err.Error()
+		}
+		c.logger.Warn("%s %s tentativa %d/%d falhou (%s), repetindo em %s", method, path, attempt, c.retry.MaxAttempts, failure, delay.Round(time.Millisecond))
+		time.Sleep(delay)
 	}
 	defer resp.Body.Close()
 
@@ -126,10 +169,11 @@ func (c *APIClient) Request(method, path string, body interface{}, requiresAuth
 
 	// Log do resultado
 	statusOK := resp.StatusCode >= 200 && resp.StatusCode < 300
+	log := c.logger.With(Fields{"method": method, "path": path, "status": resp.StatusCode, "duration_ms": duration.Milliseconds()})
 	if statusOK {
-		c.logger.Success("%s %s (status: %d, %dms)", method, path, resp.StatusCode, duration.Milliseconds())
+		log.Success("%s %s (status: %d, %dms)", method, path, resp.StatusCode, duration.Milliseconds())
 	} else {
-		c.logger.Warn("%s %s (status: %d, %dms)", method, path, resp.StatusCode, duration.Milliseconds())
+		log.Warn("%s %s (status: %d, %dms)", method, path, resp.StatusCode, duration.Milliseconds())
 	}
 
 	c.logger.Debug("Response: %s", string(respBody))
@@ -306,10 +350,11 @@ func (c *APIClient) RequestWithFile(method, path string, fileData []byte, fileNa
 
 	// Log do resultado
 	statusOK := resp.StatusCode >= 200 && resp.StatusCode < 300
+	log := c.logger.With(Fields{"method": method, "path": path, "status": resp.StatusCode, "duration_ms": duration.Milliseconds()})
 	if statusOK {
-		c.logger.Success("%s %s (status: %d, %dms)", method, path, resp.StatusCode, duration.Milliseconds())
+		log.Success("%s %s (status: %d, %dms)", method, path, resp.StatusCode, duration.Milliseconds())
 	} else {
-		c.logger.Warn("%s %s (status: %d, %dms)", method, path, resp.StatusCode, duration.Milliseconds())
+		log.Warn("%s %s (status: %d, %dms)", method, path, resp.StatusCode, duration.Milliseconds())
 	}
 
 	c.logger.Debug("Response: %s", string(respBody))
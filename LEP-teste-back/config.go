@@ -1,5 +1,11 @@
 package main
 
+import (
+	"fmt"
+
+	"lep-shared/logging"
+)
+
 // TestUser representa credenciais para testes
 type TestUser struct {
 	Email    string
@@ -31,6 +37,14 @@ type Config struct {
 
 	// Verbose logging
 	Verbose bool
+
+	// Log: nível (debug, info, warn, error), formato (text, json) e arquivo opcional
+	LogLevel  string
+	LogFormat string
+	LogFile   string
+
+	// Número máximo de tentativas por requisição em falhas transitórias (1 desativa retry)
+	MaxRetries int
 }
 
 // GetDefaultConfig retorna configuração padrão
@@ -52,6 +66,25 @@ func GetDefaultConfig() Config {
 			OrgID:  "",
 			ProjID: "",
 		},
-		Verbose: false,
+		Verbose:    false,
+		LogLevel:   "info",
+		LogFormat:  "text",
+		MaxRetries: 3,
+	}
+}
+
+// LogOptions converte a configuração de log nas opções do Logger
+func (c Config) LogOptions() (logging.Options, error) {
+	level, err := logging.ParseLevel(c.LogLevel)
+	if err != nil {
+		return logging.Options{}, err
+	}
+	if c.Verbose {
+		level = logging.LevelDebug
 	}
+	if c.LogFormat != "text" && c.LogFormat != "json" {
+		return logging.Options{}, fmt.Errorf("formato de log inválido %q (use text ou json)", c.LogFormat)
+	}
+
+	return logging.Options{Level: level, JSON: c.LogFormat == "json", File: c.LogFile, Timestamps: true}, nil
 }
@@ -2,4 +2,10 @@ module lep-teste-back
 
 go 1.21
 
-require github.com/google/uuid v1.5.0
+require (
+	github.com/google/uuid v1.5.0
+	lep-shared v0.0.0
+)
+
+// Código comum com LEP-execute-seed (logger e política de retry)
+replace lep-shared => ../LEP-shared
@@ -1,75 +1,76 @@
 package main
 
-import (
-	"fmt"
-	"time"
-)
+import "lep-shared/logging"
 
-// Color codes for terminal output
-const (
-	ColorReset   = "\033[0m"
-	ColorRed     = "\033[31m"
-	ColorGreen   = "\033[32m"
-	ColorYellow  = "\033[33m"
-	ColorBlue    = "\033[34m"
-	ColorMagenta = "\033[35m"
-	ColorCyan    = "\033[36m"
-	ColorGray    = "\033[90m"
-)
+// Fields são campos estruturados da entrada (test, method, path, status, duration_ms)
+type Fields = logging.Fields
 
+// Logger é o logger comum (lep-shared/logging) com os cabeçalhos e o resumo da suíte de testes
 type Logger struct {
-	Verbose bool
+	*logging.Logger
 }
 
+// NewLogger cria um logger de texto no stdout, com horário e nível debug quando verbose
 func NewLogger(verbose bool) *Logger {
-	return &Logger{Verbose: verbose}
-}
-
-func (l *Logger) timestamp() string {
-	return fmt.Sprintf("[%s]", time.Now().Format("15:04:05.000"))
-}
-
-func (l *Logger) Info(msg string, args ...interface{}) {
-	fmt.Printf("%s %s%s%s\n", l.timestamp(), ColorBlue, fmt.Sprintf(msg, args...), ColorReset)
-}
-
-func (l *Logger) Success(msg string, args ...interface{}) {
-	fmt.Printf("%s %s✓ %s%s\n", l.timestamp(), ColorGreen, fmt.Sprintf(msg, args...), ColorReset)
-}
-
-func (l *Logger) Error(msg string, args ...interface{}) {
-	fmt.Printf("%s %s✗ %s%s\n", l.timestamp(), ColorRed, fmt.Sprintf(msg, args...), ColorReset)
+	level := logging.LevelInfo
+	if verbose {
+		level = logging.LevelDebug
+	}
+	logger, _ := NewLoggerWithOptions(logging.Options{Level: level, Timestamps: true})
+	return logger
 }
 
-func (l *Logger) Warn(msg string, args ...interface{}) {
-	fmt.Printf("%s %s⚠ %s%s\n", l.timestamp(), ColorYellow, fmt.Sprintf(msg, args...), ColorReset)
+// NewLoggerWithOptions cria o logger; falha apenas se o arquivo de log não puder ser aberto
+func NewLoggerWithOptions(opts logging.Options) (*Logger, error) {
+	logger, err := logging.New(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Logger{logger}, nil
 }
 
-func (l *Logger) Debug(msg string, args ...interface{}) {
-	if l.Verbose {
-		fmt.Printf("%s %s[DEBUG] %s%s\n", l.timestamp(), ColorGray, fmt.Sprintf(msg, args...), ColorReset)
-	}
+// With retorna um logger que anexa os campos a todas as entradas
+func (l *Logger) With(fields Fields) *Logger {
+	return &Logger{l.Logger.With(fields)}
 }
 
+// Section abre um grupo de testes; o título vira o campo "step" das entradas seguintes
 func (l *Logger) Section(title string) {
-	fmt.Printf("\n%s%s═══════════════════════════════════════════════════════════════%s\n", ColorMagenta, ColorCyan, ColorReset)
-	fmt.Printf("%s%s  %s%s\n", ColorMagenta, ColorCyan, title, ColorReset)
-	fmt.Printf("%s%s═══════════════════════════════════════════════════════════════%s\n\n", ColorMagenta, ColorCyan, ColorReset)
+	l.SetStep(title)
+	if l.JSON() {
+		l.Event(logging.LevelInfo, "section", title, nil)
+		return
+	}
+	cyan, reset := l.Color(logging.ColorCyan), l.Color(logging.ColorReset)
+	rule := "═══════════════════════════════════════════════════════════════"
+	l.Printf(logging.LevelInfo, "\n%s%s%s\n", cyan, rule, reset)
+	l.Printf(logging.LevelInfo, "%s  %s%s\n", cyan, title, reset)
+	l.Printf(logging.LevelInfo, "%s%s%s\n\n", cyan, rule, reset)
 }
 
 func (l *Logger) Subsection(title string) {
-	fmt.Printf("\n%s▸ %s%s\n", ColorCyan, title, ColorReset)
+	if l.JSON() {
+		l.Event(logging.LevelInfo, "subsection", title, nil)
+		return
+	}
+	l.Printf(logging.LevelInfo, "\n%s▸ %s%s\n", l.Color(logging.ColorCyan), title, l.Color(logging.ColorReset))
 }
 
 func (l *Logger) Stats(total, passed, failed int) {
-	fmt.Printf("\n%s=== RESUMO ===%s\n", ColorCyan, ColorReset)
-	fmt.Printf("Total:  %d\n", total)
-	fmt.Printf("%s%s✓ Sucesso: %d%s\n", ColorGreen, "", passed, ColorReset)
-	fmt.Printf("%s%s✗ Falhas:  %d%s\n", ColorRed, "", failed, ColorReset)
+	if l.JSON() {
+		l.Event(logging.LevelInfo, "stats", "RESUMO", Fields{"total": total, "passed": passed, "failed": failed})
+		return
+	}
+
+	reset := l.Color(logging.ColorReset)
+	l.Printf(logging.LevelInfo, "\n%s=== RESUMO ===%s\n", l.Color(logging.ColorCyan), reset)
+	l.Printf(logging.LevelInfo, "Total:  %d\n", total)
+	l.Printf(logging.LevelInfo, "%s✓ Sucesso: %d%s\n", l.Color(logging.ColorGreen), passed, reset)
+	l.Printf(logging.LevelInfo, "%s✗ Falhas:  %d%s\n", l.Color(logging.ColorRed), failed, reset)
 
 	if failed == 0 {
-		fmt.Printf("\n%s🎉 TODOS OS TESTES PASSARAM! 🎉%s\n\n", ColorGreen, ColorReset)
+		l.Printf(logging.LevelInfo, "\n%s🎉 TODOS OS TESTES PASSARAM! 🎉%s\n\n", l.Color(logging.ColorGreen), reset)
 	} else {
-		fmt.Printf("\n%s⚠️ ALGUNS TESTES FALHARAM ⚠️%s\n\n", ColorRed, ColorReset)
+		l.Printf(logging.LevelInfo, "\n%s⚠️ ALGUNS TESTES FALHARAM ⚠️%s\n\n", l.Color(logging.ColorRed), reset)
 	}
 }
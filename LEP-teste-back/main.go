@@ -9,14 +9,39 @@ import (
 func main() {
 	// Parse optional flags
 	verbose := flag.Bool("verbose", false, "Enable verbose logging")
+	retries := flag.Int("retries", 0, "Max attempts per request on transient failures (default from config)")
+	logLevel := flag.String("log-level", "", "Log level: debug, info, warn or error (default from config)")
+	logFormat := flag.String("log-format", "", "Log format: text or json (default from config)")
+	logFile := flag.String("log-file", "", "Also write the log to this file")
 	flag.Parse()
 
-	// Create logger
-	logger := NewLogger(*verbose)
-
 	// Load configuration (hardcoded no config.go)
 	config := GetDefaultConfig()
 	config.Verbose = *verbose
+	if *retries > 0 {
+		config.MaxRetries = *retries
+	}
+	if *logLevel != "" {
+		config.LogLevel = *logLevel
+	}
+	if *logFormat != "" {
+		config.LogFormat = *logFormat
+	}
+	if *logFile != "" {
+		config.LogFile = *logFile
+	}
+
+	// Create logger
+	logOptions, err := config.LogOptions()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "✗ %v\n", err)
+		os.Exit(2)
+	}
+	logger, err := NewLoggerWithOptions(logOptions)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "✗ %v\n", err)
+		os.Exit(2)
+	}
 
 	// Print header
 	fmt.Println()
@@ -27,6 +52,7 @@ func main() {
 
 	// Create API client
 	client := NewAPIClient(config.BackendURL, logger)
+	client.SetRetryPolicy(DefaultRetryPolicy(config.MaxRetries))
 
 	// Create test suite
 	suite := NewTestSuite(client, logger, config)
@@ -42,6 +68,7 @@ func main() {
 	fmt.Println()
 
 	// Exit with appropriate code
+	logger.Close()
 	if suite.failed > 0 {
 		os.Exit(1)
 	}
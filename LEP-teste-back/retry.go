@@ -0,0 +1,19 @@
+package main
+
+import (
+	"time"
+
+	"lep-shared/retry"
+)
+
+// DefaultRetryPolicy retorna a política padrão da suíte de testes
+func DefaultRetryPolicy(maxAttempts int) retry.Policy {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	return retry.Policy{
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     8 * time.Second,
+	}
+}